@@ -0,0 +1,101 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
+)
+
+// analyzeCmd represents the analyze command
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Reports about jobs and builds",
+}
+
+var staleOlderThan string
+var staleFormat string
+var staleFolder string
+var staleNoConfig bool
+var staleWorkers int
+
+var analyzeStale = &cobra.Command{
+	Use:   "stale",
+	Short: "list jobs not built for a long time or never built",
+	Run: func(cmd *cobra.Command, args []string) {
+		olderThan, err := jenkins.ParseAge(staleOlderThan)
+		if err != nil {
+			fmt.Printf("❌ %s\n", err)
+			os.Exit(1)
+		}
+
+		jobs, err := jenkinsMod.FindStaleJobs(staleFolder, olderThan, !staleNoConfig, staleWorkers)
+		if err != nil {
+			fmt.Printf("❌ unable to collect jobs - err: %s \n", err)
+			os.Exit(1)
+		}
+		err = jenkins.WriteStaleReport(os.Stdout, jobs, staleFormat)
+		if err != nil {
+			fmt.Printf("❌ %s\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var jobStatsBuilds int
+var jobStatsFormat string
+
+var analyzeJob = &cobra.Command{
+	Use:   "job JOB",
+	Short: "success rate, durations, failure streaks and time to recovery of a job",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jobStatsBuilds < 1 {
+			fmt.Printf("❌ --builds must be at least 1\n")
+			os.Exit(1)
+		}
+		stats, err := jenkinsMod.AnalyzeJob(args[0], jobStatsBuilds)
+		if err != nil {
+			fmt.Printf("❌ unable to collect the builds of %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+		err = jenkins.WriteJobStats(os.Stdout, stats, jobStatsFormat)
+		if err != nil {
+			fmt.Printf("❌ %s\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(analyzeCmd)
+	analyzeCmd.AddCommand(analyzeStale)
+	analyzeCmd.AddCommand(analyzeJob)
+
+	analyzeStale.Flags().StringVarP(&staleOlderThan, "older-than", "", "180d", "minimum age of the last build, i.e: 180d")
+	analyzeStale.Flags().StringVarP(&staleFormat, "format", "o", "table", "output format: table, csv or json")
+	analyzeStale.Flags().StringVarP(&staleFolder, "folder", "", "", "only jobs in folder, i.e: folder/subfolder")
+	analyzeStale.RegisterFlagCompletionFunc("folder", completeFlag(completeFolders))
+	analyzeStale.Flags().BoolVarP(&staleNoConfig, "no-config", "", false, "do not read the job config.xml looking for owners")
+	analyzeStale.Flags().IntVarP(&staleWorkers, "workers", "", jenkins.DefaultWorkers, "number of job configs read concurrently")
+
+	analyzeJob.Flags().IntVarP(&jobStatsBuilds, "builds", "", 100, "number of builds to analyze")
+	analyzeJob.Flags().StringVarP(&jobStatsFormat, "format", "o", "table", "output format: table, csv or json")
+}
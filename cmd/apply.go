@@ -0,0 +1,107 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
+)
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a configuration to a resource in Jenkins",
+}
+
+var applyView = &cobra.Command{
+	Use:   "view",
+	Short: "view related commands",
+}
+
+var applyViewFolder string
+var applyViewFile string
+
+var applyViewConfig = &cobra.Command{
+	Use:   "config",
+	Short: "apply a config.xml to a view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 || applyViewFile == "" {
+			return errors.New("❌ requires at least one argument [VIEW NAME] and -f xmlFile")
+		}
+
+		fmt.Printf("⏳ Updating the view %s...\n", args[0])
+		err := jenkinsMod.ViewApplyConfig(applyViewFolder, args[0], applyViewFile)
+		if err != nil {
+			fmt.Printf("unable to update the view: %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+		fmt.Printf("Updated view: %s\n", args[0])
+		return nil
+	},
+}
+
+var applyJobFile string
+var applyJobValues []string
+var applyJobSets []string
+
+var applyJob = &cobra.Command{
+	Use:   "job",
+	Short: "create or update a job from a XML file or template (.tmpl, --set, --values)",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 || applyJobFile == "" {
+			return errors.New("❌ requires at least one argument [JOB NAME] and -f xmlFile")
+		}
+
+		config, err := jenkins.LoadJobConfig(applyJobFile, applyJobValues, applyJobSets)
+		if err != nil {
+			fmt.Printf("unable to load the job config: %s - err: %s \n", applyJobFile, err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("⏳ Applying the job %s...\n", args[0])
+		created, err := jenkinsMod.ApplyJob(config, args[0])
+		if err != nil {
+			fmt.Printf("unable to apply the job: %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+		if created {
+			invalidateCache(jenkins.CacheJobs)
+			fmt.Printf("Created job: %s\n", args[0])
+		} else {
+			fmt.Printf("Updated job: %s\n", args[0])
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+	applyCmd.AddCommand(applyView)
+	applyView.AddCommand(applyViewConfig)
+	applyCmd.AddCommand(applyJob)
+
+	applyViewConfig.Flags().StringVarP(&applyViewFolder, "folder", "", "", "folder full name, i.e: folder/subfolder")
+	applyViewConfig.RegisterFlagCompletionFunc("folder", completeFlag(completeFolders))
+	applyViewConfig.Flags().StringVarP(&applyViewFile, "file", "f", "", "view described in XML format")
+	applyJob.Flags().StringVarP(&applyJobFile, "file", "f", "", "job described in XML format or template")
+	applyJob.Flags().StringArrayVarP(&applyJobSets, "set", "", nil, "template value key=value, can be repeated")
+	applyJob.Flags().StringArrayVarP(&applyJobValues, "values", "", nil, "template values yaml file, can be repeated")
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// buildCmd represents the build command
+var buildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Start builds again",
+}
+
+var rebuildWait bool
+var rebuildFollow bool
+
+var buildRebuild = &cobra.Command{
+	Use:   "rebuild JOB NUMBER",
+	Short: "start a job again with the parameters of a build",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return errors.New("❌ requires two arguments: JOB NUMBER")
+		}
+
+		queueID, skipped, err := jenkinsMod.RebuildJob(args[0], args[1])
+		if err != nil {
+			fmt.Printf("❌ unable to rebuild %s #%s - err: %s \n", args[0], args[1], err)
+			os.Exit(1)
+		}
+		if len(skipped) > 0 {
+			fmt.Printf("⚠️  parameters without value use the default: %s\n", strings.Join(skipped, ", "))
+		}
+		fmt.Printf("⏳ %s #%s queued again (queue item %d)\n", args[0], args[1], queueID)
+		if !rebuildWait && !rebuildFollow {
+			return nil
+		}
+
+		number, err := jenkinsMod.WaitForQueueItem(queueID)
+		if err != nil {
+			fmt.Printf("❌ unable to wait for the queue item %d - err: %s \n", queueID, err)
+			os.Exit(1)
+		}
+		waitBuild(args[0], number, rebuildFollow)
+		return nil
+	},
+}
+
+// waitBuild will wait for a build, following its log if requested, and
+// exit with failure when the result is not SUCCESS
+func waitBuild(jobName string, number int64, follow bool) {
+	fmt.Printf("🚀 Build %s #%d started\n", jobName, number)
+	var out io.Writer
+	if follow {
+		out = os.Stdout
+	}
+	result, err := jenkinsMod.WaitForBuild(out, jobName, number)
+	if err != nil {
+		fmt.Printf("❌ unable to wait for %s #%d - err: %s \n", jobName, number, err)
+		os.Exit(1)
+	}
+	if result != "SUCCESS" {
+		fmt.Printf("❌ %s #%d finished: %s\n", jobName, number, result)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ %s #%d finished: %s\n", jobName, number, result)
+}
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+	buildCmd.AddCommand(buildRebuild)
+
+	buildRebuild.Flags().BoolVarP(&rebuildWait, "wait", "", false, "wait for the build to finish, exit with failure if not SUCCESS")
+	buildRebuild.Flags().BoolVarP(&rebuildFollow, "follow", "", false, "print the build log while waiting, implies --wait")
+}
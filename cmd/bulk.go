@@ -0,0 +1,136 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
+)
+
+// bulkFlags are the flags shared by the commands that accept --selector
+type bulkFlags struct {
+	selector string
+	fromFile string
+	yes      bool
+	workers  int
+}
+
+// enabled returns true when the jobs are selected by flags
+func (f *bulkFlags) enabled() bool {
+	return f.selector != "" || f.fromFile != ""
+}
+
+func (f *bulkFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.selector, "selector", "", "", "select jobs by name=REGEX,folder=PATH,view=NAME,color=COLOR,notbuilt=DAYS")
+	cmd.Flags().StringVarP(&f.fromFile, "from-file", "", "", "read job full names from a file (analyze csv/json report or one per line, - for stdin)")
+	cmd.Flags().BoolVarP(&f.yes, "yes", "y", false, "do not ask for confirmation")
+	cmd.Flags().IntVarP(&f.workers, "workers", "", jenkins.DefaultWorkers, "number of jobs changed concurrently")
+}
+
+// confirm asks a yes/no question in the terminal
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// runBulk will select the jobs, ask for confirmation and apply the action
+// to all of them, it exits 1 when any job fails
+//
+// Args:
+//	action - enable, disable or delete
+//	flags - bulk flags
+func runBulk(action string, flags *bulkFlags) {
+	if flags.fromFile != "" {
+		names, err := jenkins.ReadJobList(flags.fromFile)
+		if err != nil {
+			fmt.Printf("❌ unable to read %s - err: %s \n", flags.fromFile, err)
+			os.Exit(1)
+		}
+		if flags.fromFile == "-" && !flags.yes {
+			fmt.Printf("❌ --yes is required when reading jobs from stdin\n")
+			os.Exit(1)
+		}
+		applyBulk(action, names, flags)
+		return
+	}
+
+	selector, err := jenkins.ParseSelector(flags.selector)
+	if err != nil {
+		fmt.Printf("❌ %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("⏳ Collecting all job(s) information...\n")
+	jobs, err := jenkinsMod.SelectJobs(selector)
+	if err != nil {
+		fmt.Printf("❌ unable to select jobs - err: %s \n", err)
+		os.Exit(1)
+	}
+	var names []string
+	for _, job := range jobs {
+		names = append(names, job.FullName)
+	}
+	applyBulk(action, names, flags)
+}
+
+// applyBulk will show the jobs, ask for confirmation and apply the action
+// concurrently, it exits 1 when any job fails
+func applyBulk(action string, names []string, flags *bulkFlags) {
+	if len(names) == 0 {
+		fmt.Printf("No jobs matched\n")
+		return
+	}
+
+	for _, name := range names {
+		fmt.Printf("    %s\n", name)
+	}
+	if !flags.yes && !confirm(fmt.Sprintf("%s %d job(s)?", strings.Title(action), len(names))) {
+		fmt.Printf("Aborted\n")
+		os.Exit(1)
+	}
+
+	results, err := jenkinsMod.BulkJobAction(names, action, flags.workers)
+	if err != nil {
+		fmt.Printf("❌ %s\n", err)
+		os.Exit(1)
+	}
+
+	if action == "delete" {
+		invalidateCache(jenkins.CacheJobs)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			fmt.Printf("❌ %s - err: %s\n", r.Job, r.Err)
+			continue
+		}
+		fmt.Printf("✅ %s\n", r.Job)
+	}
+	fmt.Printf("Job(s) %s: %d succeeded, %d failed\n", action+"d", len(results)-failed, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Local cache of job, node, view and plugin names",
+}
+
+var cacheClearAll bool
+
+var cacheClear = &cobra.Command{
+	Use:         "clear",
+	Short:       "remove the cached names of the current config, or of all configs with --all",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		if cacheClearAll {
+			if err := jenkins.ClearAllCaches(); err != nil {
+				fmt.Printf("❌ unable to clear the cache - err: %s \n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("✅ Cache cleared\n")
+			return
+		}
+
+		config, err := loadConfig()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		cache, err := jenkins.NewCache(config, cacheTTL)
+		if err == nil {
+			err = cache.Clear()
+		}
+		if err != nil {
+			fmt.Printf("❌ unable to clear the cache - err: %s \n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Cache cleared: %s\n", cache.Dir)
+	},
+}
+
+// cachedNames returns a list of names for shell completion, the server is
+// only used when the cache is missing, expired or disabled with --no-cache
+//
+// Args:
+//	name - list name, i.e: jenkins.CacheJobs
+//	fetch - collects the list from the server
+//
+// Returns
+//	list of names, nil or error
+func cachedNames(name string, fetch func() ([]string, error)) ([]string, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	var cache *jenkins.Cache
+	if !noCache {
+		if cache, err = jenkins.NewCache(config, cacheTTL); err != nil {
+			return nil, err
+		}
+	}
+	return jenkins.CachedNames(cache, name, func() ([]string, error) {
+		if err := jenkinsMod.Init(config); err != nil {
+			return nil, err
+		}
+		return fetch()
+	})
+}
+
+// viewsCacheName returns the cached list with the views of the root or of
+// a folder
+func viewsCacheName(folder string) string {
+	folder = strings.Trim(folder, "/")
+	if folder == "" {
+		return jenkins.CacheViews
+	}
+	return jenkins.CacheViews + "/" + folder
+}
+
+// invalidateCache removes the cached lists changed by a command, errors are
+// ignored since the lists also expire with --cache-ttl
+//
+// Args:
+//	names - list names, i.e: jenkins.CacheJobs
+//
+// Returns
+func invalidateCache(names ...string) {
+	config, err := loadConfig()
+	if err != nil {
+		return
+	}
+	cache, err := jenkins.NewCache(config, cacheTTL)
+	if err != nil {
+		return
+	}
+	for _, name := range names {
+		_ = cache.Delete(name)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClear)
+
+	cacheClear.Flags().BoolVarP(&cacheClearAll, "all", "", false, "remove the cache of all configs")
+}
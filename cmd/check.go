@@ -0,0 +1,167 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
+)
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Health checks for monitoring systems",
+}
+
+var checkFormat string
+var checkOutput string
+var checkThresholds jenkins.NodeThresholds
+var checkDiskWarning, checkDiskCritical string
+var checkSwapWarning, checkSwapCritical string
+var checkTempWarning, checkTempCritical string
+
+var checkNodes = &cobra.Command{
+	Use:   "nodes",
+	Short: "check nodes health (exit codes: 0 OK, 1 WARNING, 2 CRITICAL, 3 UNKNOWN)",
+	// connects in Run, an unreachable server must be reported as UNKNOWN
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		sizes := []struct {
+			value string
+			dest  *int64
+		}{
+			{checkDiskWarning, &checkThresholds.DiskWarning},
+			{checkDiskCritical, &checkThresholds.DiskCritical},
+			{checkSwapWarning, &checkThresholds.SwapWarning},
+			{checkSwapCritical, &checkThresholds.SwapCritical},
+			{checkTempWarning, &checkThresholds.TempWarning},
+			{checkTempCritical, &checkThresholds.TempCritical},
+		}
+		for _, s := range sizes {
+			size, err := jenkins.ParseSize(s.value)
+			if err != nil {
+				fmt.Printf("NODES UNKNOWN - %s\n", err)
+				os.Exit(jenkins.StatusUnknown)
+			}
+			*s.dest = size
+		}
+
+		config, err := loadConfig()
+		if err != nil {
+			fmt.Printf("NODES UNKNOWN - %s\n", err)
+			os.Exit(jenkins.StatusUnknown)
+		}
+		jenkinsMod = jenkins.Jenkins{}
+		if err = jenkinsMod.Init(config); err != nil {
+			checkUnknown(fmt.Errorf("jenkins server unreachable: %s", jenkinsMod.Server))
+		}
+
+		var out bytes.Buffer
+		status, err := jenkinsMod.CheckNodes(checkThresholds, checkFormat, &out)
+		if err != nil {
+			checkUnknown(err)
+		}
+
+		if checkOutput == "" {
+			fmt.Print(out.String())
+			os.Exit(status)
+		}
+
+		if err = writeFileAtomic(checkOutput, out.Bytes()); err != nil {
+			fmt.Printf("NODES UNKNOWN - cannot write %s: %s\n", checkOutput, err)
+			os.Exit(jenkins.StatusUnknown)
+		}
+		os.Exit(status)
+	},
+}
+
+// checkUnknown will report a server error as UNKNOWN on stdout or in the
+// --output file and exit 3
+//
+// Args:
+//	reason - why the check could not run
+//
+// Returns
+func checkUnknown(reason error) {
+	var out bytes.Buffer
+	jenkins.WriteCheckUnknown(&out, checkFormat, reason)
+	if checkOutput == "" {
+		fmt.Print(out.String())
+		os.Exit(jenkins.StatusUnknown)
+	}
+	if err := writeFileAtomic(checkOutput, out.Bytes()); err != nil {
+		fmt.Printf("NODES UNKNOWN - cannot write %s: %s\n", checkOutput, err)
+	}
+	os.Exit(jenkins.StatusUnknown)
+}
+
+// writeFileAtomic writes a file with a temporary file and a rename, so
+// textfile collectors never read a partial file. The temporary file is
+// removed when any step fails
+//
+// Args:
+//	path - file to write
+//	data - file content
+//
+// Returns
+//	nil or error
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".jenkinsctl-")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(0644)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.AddCommand(checkNodes)
+
+	checkNodes.Flags().StringVarP(&checkFormat, "format", "", "nagios", "output format: nagios or prometheus")
+	checkNodes.Flags().StringVarP(&checkOutput, "output", "o", "", "write the result to a file (i.e: node_exporter textfile)")
+	checkNodes.Flags().IntVarP(&checkThresholds.OfflineWarning, "offline-warning", "", 1, "warning when offline nodes >= N (0 disables)")
+	checkNodes.Flags().IntVarP(&checkThresholds.OfflineCritical, "offline-critical", "", 0, "critical when offline nodes >= N (0 disables)")
+	checkNodes.Flags().StringVarP(&checkDiskWarning, "disk-warning", "", "", "warning when free disk space is below size, i.e: 10GB")
+	checkNodes.Flags().StringVarP(&checkDiskCritical, "disk-critical", "", "", "critical when free disk space is below size, i.e: 1GB")
+	checkNodes.Flags().StringVarP(&checkSwapWarning, "swap-warning", "", "", "warning when free swap space is below size")
+	checkNodes.Flags().StringVarP(&checkSwapCritical, "swap-critical", "", "", "critical when free swap space is below size")
+	checkNodes.Flags().StringVarP(&checkTempWarning, "temp-warning", "", "", "warning when free temp space is below size")
+	checkNodes.Flags().StringVarP(&checkTempCritical, "temp-critical", "", "", "critical when free temp space is below size")
+	checkNodes.Flags().DurationVarP(&checkThresholds.ClockWarning, "clock-warning", "", 0, "warning when clock difference is >= duration, i.e: 5s")
+	checkNodes.Flags().DurationVarP(&checkThresholds.ClockCritical, "clock-critical", "", 0, "critical when clock difference is >= duration")
+	checkNodes.Flags().DurationVarP(&checkThresholds.ResponseWarning, "response-warning", "", 0, "warning when response time is >= duration, i.e: 2s")
+	checkNodes.Flags().DurationVarP(&checkThresholds.ResponseCritical, "response-critical", "", 0, "critical when response time is >= duration")
+}
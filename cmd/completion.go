@@ -0,0 +1,237 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate the shell completion script",
+	Long: `Generate the shell completion script. Job, node, view and plugin names
+are completed from the server and kept in the local cache, see --cache-ttl.
+
+Bash:
+  $ source <(jenkinsctl completion bash)
+  # load for each session, on Linux:
+  $ jenkinsctl completion bash > /etc/bash_completion.d/jenkinsctl
+
+Zsh:
+  $ jenkinsctl completion zsh > "${fpath[1]}/_jenkinsctl"
+
+Fish:
+  $ jenkinsctl completion fish > ~/.config/fish/completions/jenkinsctl.fish
+
+PowerShell:
+  PS> jenkinsctl completion powershell | Out-String | Invoke-Expression
+`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.ExactValidArgs(1),
+	DisableFlagsInUseLine: true,
+	Annotations:           map[string]string{offlineAnnotation: ""},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletion(os.Stdout)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		}
+		return rootCmd.GenPowerShellCompletion(os.Stdout)
+	},
+}
+
+// completer completes a single positional argument or flag value
+type completer func(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completeArgs returns a ValidArgsFunction that completes each positional
+// argument with the completer in the same position, nil completers and
+// extra arguments are not completed
+func completeArgs(completers ...completer) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= len(completers) || completers[len(args)] == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completers[len(args)](cmd, toComplete)
+	}
+}
+
+// completeFlag adapts a completer to RegisterFlagCompletionFunc
+func completeFlag(c completer) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return c(cmd, toComplete)
+	}
+}
+
+// jobPaths returns the jobs and folders directly inside the folder typed
+// so far, folders keep the trailing / so the next level is completed
+//
+// Args:
+//	names - job full names, folders end with /
+//	toComplete - text typed so far, i.e: team/ap
+//	foldersOnly - skip the jobs
+//
+// Returns
+//	list of names, completion directive
+func jobPaths(names []string, toComplete string, foldersOnly bool) ([]string, cobra.ShellCompDirective) {
+	dir := toComplete[:strings.LastIndex(toComplete, "/")+1]
+	directive := cobra.ShellCompDirectiveNoFileComp
+	var paths []string
+	for _, name := range names {
+		if !strings.HasPrefix(name, toComplete) {
+			continue
+		}
+		rest := strings.TrimSuffix(name[len(dir):], "/")
+		if rest == "" || strings.Contains(rest, "/") {
+			continue
+		}
+		folder := strings.HasSuffix(name, "/")
+		if foldersOnly && !folder {
+			continue
+		}
+		if folder {
+			directive |= cobra.ShellCompDirectiveNoSpace
+		}
+		paths = append(paths, name)
+	}
+	return paths, directive
+}
+
+// completeJobs completes job full names folder by folder
+func completeJobs(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, err := cachedNames(jenkins.CacheJobs, jenkinsMod.JobNames)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return jobPaths(names, toComplete, false)
+}
+
+// completeFolders completes folder full names folder by folder
+func completeFolders(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, err := cachedNames(jenkins.CacheJobs, jenkinsMod.JobNames)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return jobPaths(names, toComplete, true)
+}
+
+// completeNodes completes node names
+func completeNodes(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, err := cachedNames(jenkins.CacheNodes, jenkinsMod.NodeNames)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeViews completes view names of the root, or of the folder given
+// with --folder
+func completeViews(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	folder, _ := cmd.Flags().GetString("folder")
+	folder = strings.Trim(folder, "/")
+	names, err := cachedNames(viewsCacheName(folder), func() ([]string, error) { return jenkinsMod.ViewNames(folder) })
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePlugins completes the short names of the installed plugins
+func completePlugins(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, err := cachedNames(jenkins.CachePlugins, jenkinsMod.PluginNames)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeViewTypes completes the view types of create view
+func completeViewTypes(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	types := make([]string, 0, len(viewTypes))
+	for t := range viewTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFiles keeps the default file completion of the shell
+func completeFiles(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveDefault
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+
+	// commands with a job as the only completed argument
+	for _, cmd := range []*cobra.Command{
+		analyzeJob,
+		applyJob,
+		artifactsCmd,
+		branchesInfo,
+		buildRebuild,
+		deleteJob,
+		disableJobCmd,
+		enableJobCmd,
+		graphBuild,
+		jobConfig,
+		jobCopy,
+		jobGetLastBuild,
+		jobGetLastSuccessfulBuild,
+		jobLastCompletedBuild,
+		jobLastFailedBuild,
+		jobLastStableBuild,
+		jobLastUnstableBuild,
+		jobMove,
+		jobRename,
+		logsCmd,
+		multibranchScan,
+		pipelineInputAbort,
+		pipelineInputList,
+		pipelineInputProceed,
+		pipelineReplay,
+		stagesInfo,
+	} {
+		cmd.ValidArgsFunction = completeArgs(completeJobs)
+	}
+
+	// commands with a view as the only completed argument
+	for _, cmd := range []*cobra.Command{applyViewConfig, deleteView, viewConfig, viewSync} {
+		cmd.ValidArgsFunction = completeArgs(completeViews)
+	}
+
+	// commands with an installed plugin as the only completed argument
+	for _, cmd := range []*cobra.Command{hasPlugin, pluginDeps, unInstallPlugin} {
+		cmd.ValidArgsFunction = completeArgs(completePlugins)
+	}
+
+	deleteNode.ValidArgsFunction = completeArgs(completeNodes)
+	createJobInView.ValidArgsFunction = completeArgs(completeJobs, completeViews)
+	createJobInFolder.ValidArgsFunction = completeArgs(completeFiles, nil, completeFolders)
+	generateCmd.ValidArgsFunction = completeArgs(completeFiles)
+	createView.ValidArgsFunction = completeArgs(nil, completeViewTypes)
+	viewRemoveJob.ValidArgsFunction = completeArgs(completeViews, completeJobs)
+
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"github.com/dougsland/jenkinsctl/jenkins"
 	"github.com/spf13/cobra"
 	"os"
 	"strconv"
@@ -38,7 +39,7 @@ var createJobInView = &cobra.Command{
 			fmt.Println("❌ requires at two arguments: JOB_NAME VIEW_NAME")
 			os.Exit(1)
 		}
-		err := jenkinsMod.AddJobToView(args[0], args[1])
+		err := jenkinsMod.AddJobToView(createViewFolder, args[1], args[0])
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -60,6 +61,7 @@ var createFolder = &cobra.Command{
 			fmt.Println(err)
 			os.Exit(1)
 		}
+		invalidateCache(jenkins.CacheJobs)
 		fmt.Printf("Created folder %s\n", args[1])
 	},
 }
@@ -88,29 +90,23 @@ var createNode = &cobra.Command{
 			fmt.Println(err)
 			os.Exit(1)
 		}
+		invalidateCache(jenkins.CacheNodes)
 
 	},
 }
 
+// viewTypes are the view classes accepted by create view
+var viewTypes = map[string]string{
+	"LIST_VIEW":      "hudson.model.ListView",
+	"NESTED_VIEW":    "hudson.plugins.nested_view.NestedView",
+	"MY_VIEW":        "hudson.model.MyView",
+	"DASHBOARD_VIEW": "hudson.plugins.view.dashboard.Dashboard",
+	"PIPELINE_VIEW":  "au.com.centrumsystems.hudson.plugin.buildpipeline.BuildPipelineView",
+}
+
 func detectViewType(view string) string {
-	viewSelected := ""
-	switch view {
-	case "LIST_VIEW":
-		viewSelected = "hudson.model.ListView"
-		break
-	case "NESTED_VIEW":
-		viewSelected = "hudson.plugins.nested_view.NestedView"
-		break
-	case "MY_VIEW":
-		viewSelected = "hudson.model.MyView"
-		break
-	case "DASHBOARD_VIEW":
-		viewSelected = "hudson.plugins.view.dashboard.Dashboard"
-		break
-	case "PIPELINE_VIEW":
-		viewSelected = "au.com.centrumsystems.hudson.plugin.buildpipeline.BuildPipelineView"
-		break
-	default:
+	viewSelected, ok := viewTypes[view]
+	if !ok {
 		fmt.Println("error: use only views supported: LIST_VIEW, NESTED_VIEW, MY_VIEW, DASHBOARD_VIEW, PIPELINE_VIEW")
 		os.Exit(1)
 	}
@@ -118,6 +114,8 @@ func detectViewType(view string) string {
 	return viewSelected
 }
 
+var createViewFolder string
+
 var createView = &cobra.Command{
 	Use:   "view",
 	Short: "create a view",
@@ -128,11 +126,12 @@ var createView = &cobra.Command{
 		}
 
 		fmt.Printf("⏳ Creating view %s...\n", args[0])
-		err := jenkinsMod.CreateView(args[0], detectViewType(args[1]))
+		err := jenkinsMod.CreateView(createViewFolder, args[0], detectViewType(args[1]))
 		if err != nil {
 			fmt.Printf("unable to create the view: %s - err: %s \n", args[1], err)
 			os.Exit(1)
 		}
+		invalidateCache(viewsCacheName(createViewFolder))
 		return nil
 	},
 }
@@ -158,25 +157,57 @@ var createJobInFolder = &cobra.Command{
 			fmt.Printf("unable to create the job: %s - err: %s \n", args[1], err)
 			os.Exit(1)
 		}
+		invalidateCache(jenkins.CacheJobs)
 		fmt.Printf("Created job %s in folder: %s\n", args[1], args[2])
 	},
 }
 
+var createJobFile string
+var createJobValues []string
+var createJobSets []string
+
 var createJob = &cobra.Command{
 	Use:   "job",
-	Short: "create a job",
+	Short: "create a job from a XML file, template (.tmpl, --set, --values) or YAML job spec (-f job.yaml)",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) != 2 {
-			return errors.New("❌ requires at least two arguments: xmlFile JobName")
+		file, jobName := createJobFile, ""
+		switch {
+		case file != "" && len(args) <= 1:
+			if len(args) == 1 {
+				jobName = args[0]
+			}
+		case file == "" && len(args) == 2:
+			file, jobName = args[0], args[1]
+		default:
+			return errors.New("❌ requires at least two arguments: xmlFile JobName or -f FILE [JobName]")
+		}
+
+		if jobName == "" && jenkins.IsJobSpec(file) {
+			spec, err := jenkins.ReadJobSpec(file)
+			if err != nil {
+				fmt.Printf("unable to load the job spec: %s - err: %s \n", file, err)
+				os.Exit(1)
+			}
+			jobName = spec.Name
+		}
+		if jobName == "" {
+			return errors.New("❌ requires JobName or name in the YAML job spec")
 		}
 
-		fmt.Printf("⏳ Creating the job %s...\n", args[0])
-		err := jenkinsMod.CreateJob(args[0], args[1])
+		config, err := jenkins.LoadJobConfig(file, createJobValues, createJobSets)
 		if err != nil {
-			fmt.Printf("unable to create the job: %s - err: %s \n", args[1], err)
+			fmt.Printf("unable to load the job config: %s - err: %s \n", file, err)
 			os.Exit(1)
 		}
-		fmt.Printf("Created job: %s\n", args[1])
+
+		fmt.Printf("⏳ Creating the job %s...\n", jobName)
+		err = jenkinsMod.CreateJob(config, jobName)
+		if err != nil {
+			fmt.Printf("unable to create the job: %s - err: %s \n", jobName, err)
+			os.Exit(1)
+		}
+		invalidateCache(jenkins.CacheJobs)
+		fmt.Printf("Created job: %s\n", jobName)
 		return nil
 	},
 }
@@ -189,4 +220,13 @@ func init() {
 	createCmd.AddCommand(createFolder)
 	createCmd.AddCommand(createJobInFolder)
 	createCmd.AddCommand(createJobInView)
+
+	// views and jobs in views of a folder
+	for _, cmd := range []*cobra.Command{createView, createJobInView} {
+		cmd.Flags().StringVarP(&createViewFolder, "folder", "", "", "folder full name, i.e: folder/subfolder")
+		cmd.RegisterFlagCompletionFunc("folder", completeFlag(completeFolders))
+	}
+	createJob.Flags().StringVarP(&createJobFile, "file", "f", "", "job XML, template or YAML job spec")
+	createJob.Flags().StringArrayVarP(&createJobSets, "set", "", nil, "template value key=value, can be repeated")
+	createJob.Flags().StringArrayVarP(&createJobValues, "values", "", nil, "template values yaml file, can be repeated")
 }
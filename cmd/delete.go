@@ -19,8 +19,10 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"github.com/spf13/cobra"
 	"os"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
 )
 
 // deleteCmd represents the delete command
@@ -39,16 +41,23 @@ var deleteNode = &cobra.Command{
 			fmt.Printf("unable to find the node: %s - err: %s \n", args[0], err)
 			os.Exit(1)
 		}
+		invalidateCache(jenkins.CacheNodes)
 		fmt.Printf("Deleted node: %s\n", args[0])
 	},
 }
 
+var deleteFlags bulkFlags
+
 var deleteJob = &cobra.Command{
 	Use:   "job",
 	Short: "delete a job",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if deleteFlags.enabled() {
+			runBulk("delete", &deleteFlags)
+			return nil
+		}
 		if len(args) != 1 {
-			return errors.New("❌ requires at least one argument")
+			return errors.New("❌ requires at least one argument, --selector or --from-file")
 		}
 
 		fmt.Printf("⏳ Deleting the job %s...\n", args[0])
@@ -57,13 +66,41 @@ var deleteJob = &cobra.Command{
 			fmt.Printf("unable to find the job: %s - err: %s \n", args[0], err)
 			os.Exit(1)
 		}
+		invalidateCache(jenkins.CacheJobs)
 		fmt.Printf("Deleted job: %s\n", args[0])
 		return nil
 	},
 }
 
+var deleteViewFolder string
+
+var deleteView = &cobra.Command{
+	Use:   "view",
+	Short: "delete a view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("❌ requires at least one argument [VIEW NAME]")
+		}
+
+		fmt.Printf("⏳ Deleting the view %s...\n", args[0])
+		err := jenkinsMod.DeleteView(deleteViewFolder, args[0])
+		if err != nil {
+			fmt.Printf("unable to delete the view: %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+		invalidateCache(viewsCacheName(deleteViewFolder))
+		fmt.Printf("Deleted view: %s\n", args[0])
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(deleteCmd)
 	deleteCmd.AddCommand(deleteJob)
+	deleteFlags.register(deleteJob)
 	deleteCmd.AddCommand(deleteNode)
+	deleteCmd.AddCommand(deleteView)
+
+	deleteView.Flags().StringVarP(&deleteViewFolder, "folder", "", "", "folder full name, i.e: folder/subfolder")
+	deleteView.RegisterFlagCompletionFunc("folder", completeFlag(completeFolders))
 }
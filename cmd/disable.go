@@ -28,13 +28,19 @@ var disableCmd = &cobra.Command{
 	Short: "Disable a resource in Jenkins",
 }
 
+var disableFlags bulkFlags
+
 // disableCmd represents the disable command
 var disableJobCmd = &cobra.Command{
 	Use:   "job",
 	Short: "Disable job",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if disableFlags.enabled() {
+			runBulk("disable", &disableFlags)
+			return nil
+		}
 		if len(args) != 1 {
-			return errors.New("❌ requires at least one argument")
+			return errors.New("❌ requires at least one argument, --selector or --from-file")
 		}
 		fmt.Printf("⏳ Disabling job %s...\n", args[0])
 
@@ -52,4 +58,5 @@ var disableJobCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(disableCmd)
 	disableCmd.AddCommand(disableJobCmd)
+	disableFlags.register(disableJobCmd)
 }
@@ -28,13 +28,19 @@ var enableCmd = &cobra.Command{
 	Short: "Enable a resource in Jenkins",
 }
 
+var enableFlags bulkFlags
+
 // enableCmd represents the enable command
 var enableJobCmd = &cobra.Command{
 	Use:   "job",
 	Short: "Enable job",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if enableFlags.enabled() {
+			runBulk("enable", &enableFlags)
+			return nil
+		}
 		if len(args) != 1 {
-			return errors.New("❌ requires at least one argument")
+			return errors.New("❌ requires at least one argument, --selector or --from-file")
 		}
 		fmt.Printf("⏳ Enabling job %s...\n", args[0])
 
@@ -52,4 +58,5 @@ var enableJobCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(enableCmd)
 	enableCmd.AddCommand(enableJobCmd)
+	enableFlags.register(enableJobCmd)
 }
@@ -0,0 +1,78 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var generateFile string
+var generateOutput string
+
+// generateCmd represents the generate command
+var generateCmd = &cobra.Command{
+	Use:         "generate [FILE]",
+	Short:       "Convert a YAML job spec into config.xml or a config.xml into YAML",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Args:        cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// FILE is the same as -f
+		if len(args) == 1 {
+			if generateFile != "" {
+				return errors.New("❌ use FILE or -f, not both")
+			}
+			generateFile = args[0]
+		}
+		if generateFile == "" {
+			return errors.New("❌ requires FILE or -f job.yaml or -f config.xml")
+		}
+
+		out, warnings, err := jenkins.ConvertJobFile(generateFile)
+		if err != nil {
+			fmt.Printf("❌ unable to convert %s - err: %s \n", generateFile, err)
+			os.Exit(1)
+		}
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", w)
+		}
+
+		if generateOutput == "" {
+			fmt.Print(out)
+			return nil
+		}
+		err = ioutil.WriteFile(generateOutput, []byte(out), 0644)
+		if err != nil {
+			fmt.Printf("❌ unable to write %s - err: %s \n", generateOutput, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Generated %s\n", generateOutput)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+
+	generateCmd.Flags().StringVarP(&generateFile, "file", "f", "", "YAML job spec (.yaml, .yml) or job config.xml, - reads XML from stdin")
+	generateCmd.Flags().StringVarP(&generateOutput, "output", "o", "", "write to file instead of stdout")
+}
@@ -59,13 +59,37 @@ var pluginsInfo = &cobra.Command{
 	},
 }
 
+var viewsFolder string
+
 var viewsInfo = &cobra.Command{
 	Use:   "views",
 	Short: "get all views",
 	Run: func(cmd *cobra.Command, args []string) {
-		err := jenkinsMod.ShowViews()
+		err := jenkinsMod.ShowViews(viewsFolder)
 		if err != nil {
-			fmt.Println("❌ cannot get all views")
+			fmt.Printf("❌ cannot get all views - err: %s \n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// View Commands
+var viewInfo = &cobra.Command{
+	Use:   "view",
+	Short: "view related commands",
+}
+
+var viewConfig = &cobra.Command{
+	Use:   "config",
+	Short: "get view config",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Println("❌ requires at least one argument [VIEW NAME]")
+			os.Exit(1)
+		}
+		err := jenkinsMod.ViewGetConfig(viewsFolder, args[0])
+		if err != nil {
+			fmt.Printf("❌ unable to find the view: %s - err: %s \n", args[0], err)
 			os.Exit(1)
 		}
 	},
@@ -81,6 +105,10 @@ var buildQueue = &cobra.Command{
 	Use:   "queue",
 	Short: "get build queue",
 	Run: func(cmd *cobra.Command, args []string) {
+		if buildQueueWatch.enabled {
+			runWatch(&buildQueueWatch, jenkinsMod.WatchQueue)
+			return
+		}
 		fmt.Printf("⏳ Collecting build queue information...\n")
 		err := jenkinsMod.ShowBuildQueue()
 		if err != nil {
@@ -196,6 +224,10 @@ var jobAll = &cobra.Command{
 	Use:   "all",
 	Short: "get all jobs",
 	Run: func(cmd *cobra.Command, args []string) {
+		if jobAllWatch.enabled {
+			runWatch(&jobAllWatch, jenkinsMod.WatchJobs)
+			return
+		}
 		fmt.Printf("⏳ Collecting all job(s) information...\n")
 		err := jenkinsMod.ShowAllJobs()
 		if err != nil {
@@ -221,10 +253,64 @@ var jobConfig = &cobra.Command{
 	},
 }
 
+var stagesInfo = &cobra.Command{
+	Use:   "stages JOB [BUILD]",
+	Short: "get the stages of a pipeline build, default is the last build",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 || len(args) > 2 {
+			fmt.Println("❌ requires at least one argument [JOB NAME] [BUILD]")
+			os.Exit(1)
+		}
+		build := "lastBuild"
+		if len(args) == 2 {
+			build = args[1]
+		}
+		err := jenkinsMod.ShowStages(args[0], build)
+		if err != nil {
+			fmt.Printf("❌ unable to collect the stages of %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+	},
+}
+
+var branchesInfo = &cobra.Command{
+	Use:   "branches JOB",
+	Short: "get the branch and pull request jobs of a multibranch project",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Println("❌ requires at least one argument [JOB NAME]")
+			os.Exit(1)
+		}
+		err := jenkinsMod.ShowBranches(args[0])
+		if err != nil {
+			fmt.Printf("❌ unable to collect the branches of %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+	},
+}
+
 // Node Commands
+var nodesExecutors bool
+
 var nodes = &cobra.Command{
 	Use:   "nodes",
 	Short: "nodes related commands",
+	Run: func(cmd *cobra.Command, args []string) {
+		if nodesWatch.enabled {
+			runWatch(&nodesWatch, jenkinsMod.WatchNodes)
+			return
+		}
+		if !nodesExecutors {
+			cmd.Help()
+			return
+		}
+		fmt.Printf("⏳ Collecting executor(s) information...\n")
+		err := jenkinsMod.ShowNodeExecutors()
+		if err != nil {
+			fmt.Printf("❌ unable to collect executors - err: %s \n", err)
+			os.Exit(1)
+		}
+	},
 }
 
 var nodesOffline = &cobra.Command{
@@ -265,11 +351,22 @@ func init() {
 	getCmd.AddCommand(connectionInfo)
 	getCmd.AddCommand(pluginsInfo)
 	getCmd.AddCommand(viewsInfo)
+	getCmd.AddCommand(viewInfo)
 	getCmd.AddCommand(nodes)
 	getCmd.AddCommand(build)
 	getCmd.AddCommand(job)
+	getCmd.AddCommand(stagesInfo)
+	getCmd.AddCommand(branchesInfo)
+
+	// views
+	viewsInfo.Flags().StringVarP(&viewsFolder, "folder", "", "", "folder full name, i.e: folder/subfolder")
+	viewInfo.PersistentFlags().StringVarP(&viewsFolder, "folder", "", "", "folder full name, i.e: folder/subfolder")
+	viewsInfo.RegisterFlagCompletionFunc("folder", completeFlag(completeFolders))
+	viewInfo.RegisterFlagCompletionFunc("folder", completeFlag(completeFolders))
+	viewInfo.AddCommand(viewConfig)
 
 	// nodes
+	nodes.Flags().BoolVarP(&nodesExecutors, "executors", "", false, "show busy and total executors per node and label")
 	nodes.AddCommand(nodesOffline)
 	nodes.AddCommand(nodesOnline)
 
@@ -0,0 +1,74 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+var graphRoot string
+var graphFolder string
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Dependency graphs of jobs and builds as Graphviz DOT or Mermaid",
+}
+
+var graphJobs = &cobra.Command{
+	Use:   "jobs",
+	Short: "graph of the upstream and downstream projects of the jobs",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := jenkinsMod.GraphJobs(os.Stdout, graphFolder, graphRoot, graphFormat)
+		if err != nil {
+			fmt.Printf("❌ unable to graph the jobs - err: %s \n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var graphBuild = &cobra.Command{
+	Use:   "build JOB NUMBER",
+	Short: "graph of the upstream builds that caused a build",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return errors.New("❌ requires two arguments: JOB NUMBER")
+		}
+		err := jenkinsMod.GraphBuild(os.Stdout, args[0], args[1], graphFormat)
+		if err != nil {
+			fmt.Printf("❌ unable to graph %s #%s - err: %s \n", args[0], args[1], err)
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+	graphCmd.AddCommand(graphJobs)
+	graphCmd.AddCommand(graphBuild)
+
+	graphCmd.PersistentFlags().StringVarP(&graphFormat, "format", "o", "dot", "output format: dot or mermaid")
+	graphJobs.Flags().StringVarP(&graphRoot, "root", "", "", "only the jobs triggered by this job, directly or not")
+	graphJobs.Flags().StringVarP(&graphFolder, "folder", "", "", "only jobs in folder, i.e: folder/subfolder")
+	graphJobs.RegisterFlagCompletionFunc("folder", completeFlag(completeFolders))
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
+)
+
+// jobCmd represents the job command
+var jobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "Copy, rename and move jobs",
+}
+
+var jobCopy = &cobra.Command{
+	Use:   "copy",
+	Short: "copy a job, i.e: copy folder/src folder/dst",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return errors.New("❌ requires at least two arguments: SRC_JOB DST_JOB")
+		}
+
+		fmt.Printf("⏳ Copying the job %s to %s...\n", args[0], args[1])
+		err := jenkinsMod.CopyJob(args[0], args[1])
+		if err != nil {
+			fmt.Printf("unable to copy the job: %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+		invalidateCache(jenkins.CacheJobs)
+		fmt.Printf("Copied job %s to %s\n", args[0], args[1])
+		return nil
+	},
+}
+
+var jobRename = &cobra.Command{
+	Use:   "rename",
+	Short: "rename a job, i.e: rename folder/old new",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return errors.New("❌ requires at least two arguments: OLD_JOB NEW_NAME")
+		}
+
+		fmt.Printf("⏳ Renaming the job %s to %s...\n", args[0], args[1])
+		err := jenkinsMod.RenameJob(args[0], args[1])
+		if err != nil {
+			fmt.Printf("unable to rename the job: %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+		invalidateCache(jenkins.CacheJobs)
+		fmt.Printf("Renamed job %s to %s\n", args[0], args[1])
+		return nil
+	},
+}
+
+var moveToFolder string
+var moveCopyDelete bool
+
+var jobMove = &cobra.Command{
+	Use:   "move",
+	Short: "move a job to another folder, --to-folder / moves to the root",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 || !cmd.Flags().Changed("to-folder") {
+			return errors.New("❌ requires at least one argument [JOB NAME] and --to-folder FOLDER")
+		}
+
+		fmt.Printf("⏳ Moving the job %s to %s...\n", args[0], moveToFolder)
+		err := jenkinsMod.MoveJob(args[0], moveToFolder, moveCopyDelete)
+		if err != nil {
+			fmt.Printf("unable to move the job: %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+		invalidateCache(jenkins.CacheJobs)
+		fmt.Printf("Moved job %s to %s\n", args[0], moveToFolder)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(jobCmd)
+	jobCmd.AddCommand(jobCopy)
+	jobCmd.AddCommand(jobRename)
+	jobCmd.AddCommand(jobMove)
+
+	jobMove.Flags().StringVarP(&moveToFolder, "to-folder", "", "", "destination folder full name")
+	jobMove.Flags().BoolVarP(&moveCopyDelete, "copy-delete", "", false, "without the move action, export, create and delete the job, the build history is lost")
+	jobMove.RegisterFlagCompletionFunc("to-folder", completeFlag(completeFolders))
+}
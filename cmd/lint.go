@@ -0,0 +1,98 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var lintServer bool
+var lintFolder string
+var lintRulesFile string
+var lintDisable []string
+var lintFormat string
+var lintWorkers int
+var lintListRules bool
+
+// lintCmd represents the lint command, it only connects to jenkins
+// with --server
+var lintCmd = &cobra.Command{
+	Use:         "lint [FILES]",
+	Short:       "Check job configs (XML, templates or YAML job specs) against policy rules",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules, err := jenkins.LoadLintRules(lintRulesFile, lintDisable)
+		if err != nil {
+			fmt.Printf("❌ unable to load the lint rules - err: %s \n", err)
+			os.Exit(1)
+		}
+
+		if lintListRules {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "RULE\tSEVERITY\tDESCRIPTION\n")
+			for _, r := range rules {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID, r.Severity, r.Description)
+			}
+			w.Flush()
+			return nil
+		}
+
+		var results []jenkins.LintResult
+		switch {
+		case lintServer && len(args) == 0:
+			initConfig()
+			results, err = jenkinsMod.LintServerJobs(lintFolder, rules, lintWorkers)
+			if err != nil {
+				fmt.Printf("❌ unable to collect jobs - err: %s \n", err)
+				os.Exit(1)
+			}
+		case !lintServer && len(args) > 0:
+			results = jenkins.LintFiles(args, rules)
+		default:
+			return errors.New("❌ requires job files or --server")
+		}
+
+		err = jenkins.WriteLintReport(os.Stdout, results, rules, lintFormat)
+		if err != nil {
+			fmt.Printf("❌ %s\n", err)
+			os.Exit(1)
+		}
+		if jenkins.LintFailed(results) {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().BoolVarP(&lintServer, "server", "", false, "lint the config.xml of the jobs in the server")
+	lintCmd.Flags().StringVarP(&lintFolder, "folder", "", "", "with --server, only jobs in folder, i.e: folder/subfolder")
+	lintCmd.RegisterFlagCompletionFunc("folder", completeFlag(completeFolders))
+	lintCmd.Flags().StringVarP(&lintRulesFile, "rules", "", "", "yaml file to enable, disable or change the severity of rules")
+	lintCmd.Flags().StringArrayVarP(&lintDisable, "disable", "", nil, "rule to disable, can be repeated")
+	lintCmd.Flags().StringVarP(&lintFormat, "format", "o", "text", "output format: text, json or junit")
+	lintCmd.Flags().IntVarP(&lintWorkers, "workers", "", jenkins.DefaultWorkers, "number of job configs read concurrently")
+	lintCmd.Flags().BoolVarP(&lintListRules, "list-rules", "", false, "list the enabled rules")
+}
@@ -0,0 +1,56 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var logsStage string
+
+// logsCmd represents the logs command
+var logsCmd = &cobra.Command{
+	Use:   "logs JOB BUILD",
+	Short: "Print the console log of a build or of a single pipeline stage",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return errors.New("❌ requires two arguments: JOB BUILD (number or lastBuild)")
+		}
+
+		var err error
+		if logsStage != "" {
+			err = jenkinsMod.WriteStageLog(os.Stdout, args[0], args[1], logsStage)
+		} else {
+			err = jenkinsMod.WriteBuildLog(os.Stdout, args[0], args[1])
+		}
+		if err != nil {
+			fmt.Printf("❌ unable to get the log of %s #%s - err: %s \n", args[0], args[1], err)
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd)
+
+	logsCmd.Flags().StringVarP(&logsStage, "stage", "", "", "only the log of the steps of this pipeline stage")
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// multibranchCmd represents the multibranch command
+var multibranchCmd = &cobra.Command{
+	Use:   "multibranch",
+	Short: "Multibranch projects and organization folders",
+}
+
+var multibranchScan = &cobra.Command{
+	Use:   "scan JOB",
+	Short: "start the branch indexing and follow the indexing log",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Println("❌ requires one argument: JOB")
+			os.Exit(1)
+		}
+		fmt.Printf("⏳ Scanning %s...\n", args[0])
+		err := jenkinsMod.ScanMultibranch(os.Stdout, args[0])
+		if err != nil {
+			fmt.Printf("❌ unable to scan %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Scan of %s finished\n", args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(multibranchCmd)
+	multibranchCmd.AddCommand(multibranchScan)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// pipelineCmd represents the pipeline command
+var pipelineCmd = &cobra.Command{
+	Use:   "pipeline",
+	Short: "Pipeline related commands",
+}
+
+var pipelineValidate = &cobra.Command{
+	Use:   "validate JENKINSFILE",
+	Short: "validate a declarative Jenkinsfile with the server linter",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("❌ requires one argument: JENKINSFILE")
+		}
+		data, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			fmt.Printf("❌ unable to read %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+
+		errs, err := jenkinsMod.ValidateJenkinsfile(string(data))
+		if err != nil {
+			fmt.Printf("❌ unable to validate %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+		if len(errs) == 0 {
+			fmt.Printf("✅ %s successfully validated\n", args[0])
+			return nil
+		}
+
+		lines := strings.Split(string(data), "\n")
+		for _, e := range errs {
+			if e.Line == 0 {
+				fmt.Printf("❌ %s: %s\n", args[0], e.Message)
+				continue
+			}
+			fmt.Printf("❌ %s:%d:%d: %s\n", args[0], e.Line, e.Column, e.Message)
+			if e.Line <= len(lines) {
+				fmt.Printf("    %s\n", strings.Replace(lines[e.Line-1], "\t", " ", -1))
+				if e.Column > 0 {
+					fmt.Printf("    %s^\n", strings.Repeat(" ", e.Column-1))
+				}
+			}
+		}
+		fmt.Printf("\nNumber of errors: %d\n", len(errs))
+		os.Exit(1)
+		return nil
+	},
+}
+
+var pipelineInput = &cobra.Command{
+	Use:   "input",
+	Short: "list, approve or reject pending input steps",
+}
+
+var pipelineInputList = &cobra.Command{
+	Use:   "list JOB BUILD",
+	Short: "list the input steps waiting for approval",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			fmt.Println("❌ requires two arguments: JOB BUILD")
+			os.Exit(1)
+		}
+		err := jenkinsMod.ShowPendingInputs(args[0], args[1])
+		if err != nil {
+			fmt.Printf("❌ unable to collect the pending inputs - err: %s \n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var inputParams []string
+
+var pipelineInputProceed = &cobra.Command{
+	Use:   "proceed JOB BUILD INPUT_ID",
+	Short: "approve an input step, parameters are set with -p KEY=VALUE",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 3 {
+			fmt.Println("❌ requires three arguments: JOB BUILD INPUT_ID")
+			os.Exit(1)
+		}
+		params, err := parseParams(inputParams)
+		if err != nil {
+			fmt.Printf("❌ %s\n", err)
+			os.Exit(1)
+		}
+		err = jenkinsMod.ProceedInput(args[0], args[1], args[2], params)
+		if err != nil {
+			fmt.Printf("❌ unable to proceed input %s - err: %s \n", args[2], err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Input %s approved\n", args[2])
+	},
+}
+
+var pipelineInputAbort = &cobra.Command{
+	Use:   "abort JOB BUILD INPUT_ID",
+	Short: "reject an input step, the build is aborted",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 3 {
+			fmt.Println("❌ requires three arguments: JOB BUILD INPUT_ID")
+			os.Exit(1)
+		}
+		err := jenkinsMod.AbortInput(args[0], args[1], args[2])
+		if err != nil {
+			fmt.Printf("❌ unable to abort input %s - err: %s \n", args[2], err)
+			os.Exit(1)
+		}
+		fmt.Printf("🚫 Input %s aborted\n", args[2])
+	},
+}
+
+var replayScript string
+var replayWait bool
+var replayFollow bool
+
+var pipelineReplay = &cobra.Command{
+	Use:   "replay JOB NUMBER",
+	Short: "run a pipeline build again with a changed Jenkinsfile",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 || replayScript == "" {
+			return errors.New("❌ requires two arguments: JOB NUMBER and --script Jenkinsfile")
+		}
+		script, err := ioutil.ReadFile(replayScript)
+		if err != nil {
+			fmt.Printf("❌ unable to read %s - err: %s \n", replayScript, err)
+			os.Exit(1)
+		}
+
+		number, err := jenkinsMod.ReplayBuild(args[0], args[1], string(script))
+		if err != nil {
+			fmt.Printf("❌ unable to replay %s #%s - err: %s \n", args[0], args[1], err)
+			os.Exit(1)
+		}
+		fmt.Printf("⏳ %s #%s replayed as #%d\n", args[0], args[1], number)
+		if replayWait || replayFollow {
+			waitBuild(args[0], number, replayFollow)
+		}
+		return nil
+	},
+}
+
+// parseParams converts KEY=VALUE pairs into a map
+func parseParams(pairs []string) (map[string]string, error) {
+	params := map[string]string{}
+	for _, p := range pairs {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid parameter: %s (use KEY=VALUE)", p)
+		}
+		params[kv[0]] = kv[1]
+	}
+	return params, nil
+}
+
+func init() {
+	rootCmd.AddCommand(pipelineCmd)
+	pipelineCmd.AddCommand(pipelineValidate)
+	pipelineCmd.AddCommand(pipelineInput)
+	pipelineCmd.AddCommand(pipelineReplay)
+
+	pipelineInput.AddCommand(pipelineInputList)
+	pipelineInput.AddCommand(pipelineInputProceed)
+	pipelineInput.AddCommand(pipelineInputAbort)
+
+	pipelineReplay.Flags().StringVarP(&replayScript, "script", "", "", "Jenkinsfile used as the new main script")
+	pipelineReplay.Flags().BoolVarP(&replayWait, "wait", "", false, "wait for the build to finish, exit with failure if not SUCCESS")
+	pipelineReplay.Flags().BoolVarP(&replayFollow, "follow", "", false, "print the build log while waiting, implies --wait")
+
+	pipelineInputProceed.Flags().StringArrayVarP(&inputParams, "param", "p", nil, "input parameter KEY=VALUE, can be repeated")
+}
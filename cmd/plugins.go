@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/dougsland/jenkinsctl/jenkins"
 	"github.com/spf13/cobra"
 )
 
@@ -59,10 +60,39 @@ var hasPlugin = &cobra.Command{
 	},
 }
 
+var installFile string
+var installCheck bool
+
 var installPlugin = &cobra.Command{
 	Use:   "install",
-	Short: "install a plugin",
+	Short: "install a plugin [PLUGIN_NAME] [VERSION], plugins from a file (-f plugins.txt) or a plugin file (-f my-plugin.hpi)",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if installFile != "" && jenkins.IsPluginArchive(installFile) {
+			err := jenkinsMod.UploadPlugin(installFile)
+			if err != nil {
+				fmt.Printf("error cannot install the plugin: %s - %s\n", installFile, err)
+				os.Exit(1)
+			}
+			invalidateCache(jenkins.CachePlugins)
+			return nil
+		}
+
+		if installFile != "" {
+			drift, err := jenkinsMod.InstallPluginsFile(installFile, installCheck)
+			if err != nil {
+				fmt.Printf("error cannot install plugins from %s - %s\n", installFile, err)
+				os.Exit(1)
+			}
+			if !installCheck {
+				invalidateCache(jenkins.CachePlugins)
+			}
+			if installCheck && drift > 0 {
+				fmt.Printf("❌ Number of differences found: %d\n", drift)
+				os.Exit(1)
+			}
+			return nil
+		}
+
 		if len(args) != 2 {
 			return errors.New("❌ requires at least two arguments [PLUGIN_NAME] [VERSION]")
 		}
@@ -72,6 +102,7 @@ var installPlugin = &cobra.Command{
 			fmt.Printf("error cannot install the plugin: %s - %s\n", args[0], err)
 			os.Exit(1)
 		}
+		invalidateCache(jenkins.CachePlugins)
 		fmt.Printf("Plugin %s installed\n", args[0])
 		return nil
 	},
@@ -87,6 +118,25 @@ var getInfo = &cobra.Command{
 	},
 }
 
+var pluginDeps = &cobra.Command{
+	Use:   "deps",
+	Short: "show the dependency tree of a plugin and the plugins that depend on it",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("❌ requires at least one argument [PLUGIN_NAME]")
+		}
+
+		err := jenkinsMod.ShowPluginDeps(args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+var uninstallForce bool
+
 var unInstallPlugin = &cobra.Command{
 	Use:   "uninstall",
 	Short: "uninstall a plugin",
@@ -95,18 +145,66 @@ var unInstallPlugin = &cobra.Command{
 			return errors.New("❌ requires at least one argument [PLUGIN_NAME]")
 		}
 
-		_, err := jenkinsMod.Instance.HasPlugin(jenkinsMod.Context, args[0])
+		err := jenkinsMod.UninstallPlugin(args[0], uninstallForce)
+		if err != nil {
+			fmt.Printf("error cannot uninstall the plugin: %s - %s\n", args[0], err)
+			os.Exit(1)
+		}
+		invalidateCache(jenkins.CachePlugins)
+		fmt.Printf("Plugin %s uninstalled\n", args[0])
+		return nil
+	},
+}
+
+var outdatedPlugins = &cobra.Command{
+	Use:   "outdated",
+	Short: "list plugins with an update available",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("⏳ Collecting plugin(s) information...\n")
+		err := jenkinsMod.ShowOutdatedPlugins()
 		if err != nil {
-			fmt.Printf("cannot find plugin %s in the server - %s\n", args[0], err)
+			fmt.Printf("❌ cannot collect outdated plugins - %s\n", err)
 			os.Exit(1)
 		}
+	},
+}
 
-		err = jenkinsMod.Instance.UninstallPlugin(jenkinsMod.Context, args[0])
+var exportPlugins = &cobra.Command{
+	Use:   "export",
+	Short: "export installed plugins in plugins.txt format (name:version)",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := jenkinsMod.ExportPlugins(os.Stdout)
 		if err != nil {
-			fmt.Printf("error cannot uninstall the plugin: %s - %s\n", args[0], err)
+			fmt.Printf("error cannot export plugins - %s\n", err)
 			os.Exit(1)
 		}
-		fmt.Printf("Plugin %s uninstalled\n", args[0])
+	},
+}
+
+var upgradeAll bool
+var upgradeRestart bool
+
+var upgradePlugins = &cobra.Command{
+	Use:   "upgrade",
+	Short: "upgrade plugins [PLUGIN_NAME...] or --all",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 && !upgradeAll {
+			return errors.New("❌ requires at least one argument [PLUGIN_NAME] or --all")
+		}
+
+		upgraded, err := jenkinsMod.UpgradePlugins(args, upgradeAll)
+		if err != nil {
+			fmt.Printf("error cannot upgrade plugins - %s\n", err)
+			os.Exit(1)
+		}
+
+		if upgradeRestart && upgraded > 0 {
+			err = jenkinsMod.SafeRestart()
+			if err != nil {
+				fmt.Printf("error cannot restart jenkins - %s\n", err)
+				os.Exit(1)
+			}
+		}
 		return nil
 	},
 }
@@ -117,4 +215,16 @@ func init() {
 	pluginsCmd.AddCommand(installPlugin)
 	pluginsCmd.AddCommand(hasPlugin)
 	pluginsCmd.AddCommand(getInfo)
+	pluginsCmd.AddCommand(outdatedPlugins)
+	pluginsCmd.AddCommand(upgradePlugins)
+	pluginsCmd.AddCommand(exportPlugins)
+	pluginsCmd.AddCommand(pluginDeps)
+
+	unInstallPlugin.Flags().BoolVarP(&uninstallForce, "force", "", false, "uninstall even when other plugins depend on it")
+
+	installPlugin.Flags().StringVarP(&installFile, "file", "f", "", "install plugins from a plugins.txt file or upload a .hpi/.jpi file")
+	installPlugin.Flags().BoolVarP(&installCheck, "check", "", false, "only report differences between the server and the file, exit 1 on drift")
+
+	upgradePlugins.Flags().BoolVarP(&upgradeAll, "all", "", false, "upgrade all outdated plugins")
+	upgradePlugins.Flags().BoolVarP(&upgradeRestart, "restart", "", false, "safe-restart jenkins after the upgrade")
 }
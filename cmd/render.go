@@ -0,0 +1,63 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dougsland/jenkinsctl/jenkins"
+	"github.com/spf13/cobra"
+)
+
+var renderFile string
+var renderValues []string
+var renderSets []string
+
+// renderCmd represents the render command
+var renderCmd = &cobra.Command{
+	Use:         "render",
+	Short:       "Render a job XML template without touching the server",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if renderFile == "" {
+			return errors.New("❌ requires -f template")
+		}
+
+		values, err := jenkins.LoadValues(renderValues, renderSets)
+		if err != nil {
+			fmt.Printf("❌ unable to load values - err: %s \n", err)
+			os.Exit(1)
+		}
+		config, err := jenkins.RenderTemplate(renderFile, values)
+		if err != nil {
+			fmt.Printf("❌ unable to render %s - err: %s \n", renderFile, err)
+			os.Exit(1)
+		}
+		fmt.Print(config)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+
+	renderCmd.Flags().StringVarP(&renderFile, "file", "f", "", "job XML template")
+	renderCmd.Flags().StringArrayVarP(&renderSets, "set", "", nil, "template value key=value, can be repeated")
+	renderCmd.Flags().StringArrayVarP(&renderValues, "values", "", nil, "template values yaml file, can be repeated")
+}
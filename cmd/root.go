@@ -21,6 +21,7 @@ import (
 	"github.com/dougsland/jenkinsctl/jenkins"
 	"github.com/spf13/cobra"
 	"os"
+	"time"
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -34,17 +35,43 @@ var rootCmd = &cobra.Command{
 var jenkinsMod jenkins.Jenkins
 var jenkinsConfig jenkins.Config
 var configFile string
+var requestStats bool
+var noCache bool
+var cacheTTL time.Duration
+var started time.Time
+
+// offlineAnnotation marks the commands that do not connect to jenkins
+const offlineAnnotation = "offline"
 
 func init() {
-	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if _, ok := cmd.Annotations[offlineAnnotation]; ok {
+			return
+		}
+		// shell completion connects only when the cache cannot be used
+		if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+			return
+		}
+		started = time.Now()
+		initConfig()
+	}
+	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		if requestStats && jenkinsMod.Instance != nil {
+			fmt.Fprintf(os.Stderr, "📊 %d request(s) to %s in %s\n",
+				jenkinsMod.RequestCount(), jenkinsMod.Server, time.Since(started).Round(time.Millisecond))
+		}
+	}
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "", "", "Path to config file")
+	rootCmd.PersistentFlags().BoolVarP(&noCache, "no-cache", "", false, "do not use the cached job, node, view and plugin names")
+	rootCmd.PersistentFlags().DurationVarP(&cacheTTL, "cache-ttl", "", jenkins.DefaultCacheTTL, "how long the cached names are used")
+	rootCmd.PersistentFlags().BoolVarP(&requestStats, "request-stats", "", false, "print the number of requests sent to the server")
 }
 
-func initConfig() {
+// loadConfig will read the config file without connecting to jenkins
+func loadConfig() (jenkins.Config, error) {
 	dirname, err := os.UserHomeDir()
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return jenkins.Config{}, err
 	}
 
 	if configFile != "" {
@@ -53,7 +80,11 @@ func initConfig() {
 		jenkinsConfig.SetConfigPath(dirname + "/.config/jenkinsctl/config.json")
 	}
 
-	config, err := jenkinsConfig.LoadConfig()
+	return jenkinsConfig.LoadConfig()
+}
+
+func initConfig() {
+	config, err := loadConfig()
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -0,0 +1,86 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// viewCmd represents the view command
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Manage the jobs of a view",
+}
+
+var viewFolder string
+
+var viewRemoveJob = &cobra.Command{
+	Use:   "remove-job",
+	Short: "remove a job from a view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return errors.New("❌ requires at least two arguments: VIEW_NAME JOB_NAME")
+		}
+
+		err := jenkinsMod.RemoveJobFromView(viewFolder, args[0], args[1])
+		if err != nil {
+			fmt.Printf("unable to remove the job: %s from view: %s - err: %s \n", args[1], args[0], err)
+			os.Exit(1)
+		}
+		fmt.Printf("Removed job %s from view %s\n", args[1], args[0])
+		return nil
+	},
+}
+
+var syncInclude string
+var syncExclude string
+var syncDryRun bool
+
+var viewSync = &cobra.Command{
+	Use:   "sync",
+	Short: "add and remove jobs so the view members match --include and --exclude",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 || syncInclude == "" {
+			return errors.New("❌ requires at least one argument [VIEW_NAME] and --include REGEX")
+		}
+
+		fmt.Printf("⏳ Syncing view %s...\n", args[0])
+		err := jenkinsMod.SyncView(viewFolder, args[0], syncInclude, syncExclude, syncDryRun)
+		if err != nil {
+			fmt.Printf("unable to sync the view: %s - err: %s \n", args[0], err)
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(viewCmd)
+	viewCmd.AddCommand(viewRemoveJob)
+	viewCmd.AddCommand(viewSync)
+
+	viewSync.Flags().StringVarP(&syncInclude, "include", "", "", "regex of the job full names in the view")
+	viewSync.Flags().StringVarP(&syncExclude, "exclude", "", "", "regex of the job full names excluded from the view")
+	viewSync.Flags().BoolVarP(&syncDryRun, "dry-run", "", false, "only show the changes")
+
+	viewCmd.PersistentFlags().StringVarP(&viewFolder, "folder", "", "", "folder full name, i.e: folder/subfolder")
+	viewCmd.RegisterFlagCompletionFunc("folder", completeFlag(completeFolders))
+}
@@ -0,0 +1,80 @@
+/*
+Copyright © 2021 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// watchFlags are the flags shared by the commands that accept --watch
+type watchFlags struct {
+	enabled  bool
+	interval time.Duration
+}
+
+func (f *watchFlags) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&f.enabled, "watch", "w", false, "redraw every --interval and highlight the changes")
+	cmd.Flags().DurationVarP(&f.interval, "interval", "", 5*time.Second, "time between polls with --watch")
+}
+
+// runWatch will redraw the table until Ctrl-C, the signal cancels the
+// jenkins context and the request in progress. A second Ctrl-C kills the
+// process
+//
+// Args:
+//	f - watch flags
+//	watch - redraws the table until the context is cancelled
+//
+// Returns
+//
+func runWatch(f *watchFlags, watch func(out io.Writer, interval time.Duration) error) {
+	if f.interval < time.Second {
+		fmt.Println("❌ --interval must be at least 1s")
+		os.Exit(1)
+	}
+	ctx, stop := signal.NotifyContext(jenkinsMod.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	jenkinsMod.Context = ctx
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := watch(os.Stdout, f.interval)
+	if err != nil {
+		fmt.Printf("❌ %s\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("\n👋 Watch stopped\n")
+}
+
+var jobAllWatch watchFlags
+var buildQueueWatch watchFlags
+var nodesWatch watchFlags
+
+func init() {
+	jobAllWatch.register(jobAll)
+	buildQueueWatch.register(buildQueue)
+	nodesWatch.register(nodes)
+}
@@ -6,3 +6,5 @@ require (
 	github.com/dougsland/jenkinsctl/jenkins v0.0.0-20210621004651-0e2c28d94c9c
 	github.com/spf13/cobra v1.1.3
 )
+
+replace github.com/dougsland/jenkinsctl/jenkins => ./jenkins
@@ -0,0 +1,202 @@
+package jenkins
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// StaleJob is a job not built for a long time or never built
+type StaleJob struct {
+	Job            string   `json:"job"`
+	URL            string   `json:"url"`
+	Disabled       bool     `json:"disabled"`
+	LastBuild      string   `json:"lastBuild"`
+	DaysSinceBuild int      `json:"daysSinceBuild"`
+	Owners         []string `json:"owners"`
+	// ConfigError is set when config.xml could not be read, the owners
+	// only come from the description
+	ConfigError string `json:"configError,omitempty"`
+}
+
+var (
+	emailRe = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	ownerRe = regexp.MustCompile(`(?i)\b(?:owner|maintainer|contact)\s*[:=]\s*([^\s,;<]+)`)
+	// ownership plugin
+	ownerIDRe   = regexp.MustCompile(`<(?:primaryOwnerId|coOwnerId|string)>([^<]+)</(?:primaryOwnerId|coOwnerId|string)>`)
+	ownershipRe = regexp.MustCompile(`(?s)<ownership>.*?</ownership>`)
+)
+
+// ParseAge will convert ages like 180d, 12h or 90 (days) into a duration
+//
+// Args:
+//	age - number of days with optional d suffix or a Go duration
+//
+// Returns
+//	duration, nil or error
+func ParseAge(age string) (time.Duration, error) {
+	value := strings.TrimSpace(age)
+	if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil {
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age: %s (use i.e: 180d)", age)
+	}
+	return d, nil
+}
+
+// ownerHints will collect owner hints from a job description and config
+//
+// Args:
+//	description - job description
+//	config - job config.xml, may be empty
+//
+// Returns
+//	list of owners
+func ownerHints(description string, config string) []string {
+	found := map[string]bool{}
+	for _, email := range emailRe.FindAllString(description+"\n"+config, -1) {
+		found[strings.ToLower(email)] = true
+	}
+	for _, m := range ownerRe.FindAllStringSubmatch(description, -1) {
+		found[strings.TrimRight(m[1], ".:")] = true
+	}
+	for _, block := range ownershipRe.FindAllString(config, -1) {
+		for _, m := range ownerIDRe.FindAllStringSubmatch(block, -1) {
+			found[strings.TrimSpace(m[1])] = true
+		}
+	}
+
+	owners := make([]string, 0, len(found))
+	for o := range found {
+		owners = append(owners, o)
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+// FindStaleJobs will list the jobs whose last build is older than
+// olderThan or that were never built
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	olderThan - minimum age of the last build
+//	withConfig - read the job config.xml looking for owners
+//	workers - max number of concurrent requests
+//
+// Returns
+//	list of stale jobs, nil or error
+func (j *Jenkins) FindStaleJobs(folder string, olderThan time.Duration, withConfig bool, workers int) ([]StaleJob, error) {
+	jobs, err := j.ListJobs(folder, "description", "lastBuild[number,result,timestamp]")
+	if err != nil {
+		return nil, err
+	}
+
+	limit := time.Now().Add(-olderThan)
+	var stale []*JobInfo
+	for _, job := range jobs {
+		if job.LastBuild == nil || job.LastBuildTime().Before(limit) {
+			stale = append(stale, job)
+		}
+	}
+
+	configs := make([]string, len(stale))
+	configErrors := make([]error, len(stale))
+	if withConfig {
+		runParallel(len(stale), workers, func(i int) {
+			configs[i], configErrors[i] = j.getJobConfig(stale[i].FullName)
+		})
+	}
+
+	result := make([]StaleJob, len(stale))
+	for i, job := range stale {
+		s := StaleJob{
+			Job:            job.FullName,
+			URL:            job.URL,
+			Disabled:       job.Color == "disabled",
+			LastBuild:      "never",
+			DaysSinceBuild: -1,
+			Owners:         ownerHints(job.Description, configs[i]),
+		}
+		if configErrors[i] != nil {
+			s.ConfigError = configErrors[i].Error()
+		}
+		if job.LastBuild != nil {
+			s.LastBuild = job.LastBuildTime().Format(time.RFC3339)
+			s.DaysSinceBuild = int(time.Since(job.LastBuildTime()).Hours() / 24)
+		}
+		result[i] = s
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].DaysSinceBuild != result[b].DaysSinceBuild {
+			// never built first, then the oldest
+			if result[a].DaysSinceBuild < 0 || result[b].DaysSinceBuild < 0 {
+				return result[a].DaysSinceBuild < 0
+			}
+			return result[a].DaysSinceBuild > result[b].DaysSinceBuild
+		}
+		return result[a].Job < result[b].Job
+	})
+	return result, nil
+}
+
+// WriteStaleReport will write the stale jobs as table, csv or json. The
+// csv and json reports can be used as --from-file in the bulk commands
+//
+// Args:
+//	out - where the report is written
+//	jobs - stale jobs
+//	format - table, csv or json
+//
+// Returns
+//	nil or error
+func WriteStaleReport(out io.Writer, jobs []StaleJob, format string) error {
+	switch format {
+	case "json":
+		if jobs == nil {
+			jobs = []StaleJob{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(jobs)
+	case "csv":
+		w := csv.NewWriter(out)
+		w.Write([]string{"job", "lastBuild", "daysSinceBuild", "disabled", "owners", "url", "configError"})
+		for _, s := range jobs {
+			w.Write([]string{s.Job, s.LastBuild, strconv.Itoa(s.DaysSinceBuild), strconv.FormatBool(s.Disabled), strings.Join(s.Owners, " "), s.URL, s.ConfigError})
+		}
+		w.Flush()
+		return w.Error()
+	case "table":
+		unreadable := 0
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "JOB\tLAST BUILD\tDAYS\tDISABLED\tOWNERS\n")
+		for _, s := range jobs {
+			days := strconv.Itoa(s.DaysSinceBuild)
+			if s.DaysSinceBuild < 0 {
+				days = "-"
+			}
+			owners := strings.Join(s.Owners, " ")
+			if s.ConfigError != "" {
+				owners = strings.TrimSpace(owners + " (config unreadable)")
+				unreadable++
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\n", s.Job, s.LastBuild, days, s.Disabled, owners)
+		}
+		w.Flush()
+		fmt.Fprintf(out, "\nNumber of stale jobs: %d\n", len(jobs))
+		if unreadable > 0 {
+			fmt.Fprintf(out, "⚠️  config.xml of %d job(s) could not be read, owners may be missing\n", unreadable)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format: %s (use table, csv or json)", format)
+}
@@ -0,0 +1,101 @@
+package jenkins
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bndr/gojenkins"
+)
+
+// getJSON will fetch endpoint/api/json and decode it into response
+//
+// Args:
+//	endpoint - path in the server, i.e: /computer
+//	response - pointer to the struct that receives the data
+//	query - query string parameters like tree and depth
+//
+// Returns
+//	nil or error
+func (j *Jenkins) getJSON(endpoint string, response interface{}, query map[string]string) error {
+	resp, err := j.Instance.Requester.GetJSON(j.Context, endpoint, response, query)
+	if err != nil {
+		return err
+	}
+	return checkStatus(resp, endpoint)
+}
+
+// postText will post a form to endpoint and return the response body as
+// text, Requester.Post always decodes the response as JSON
+//
+// Args:
+//	endpoint - path in the server
+//	payload - url encoded form
+//	query - query string parameters
+//
+// Returns
+//	response body, http response, nil or error
+func (j *Jenkins) postText(endpoint string, payload io.Reader, query map[string]string) (string, *http.Response, error) {
+	ar := gojenkins.NewAPIRequest("POST", endpoint, payload)
+	if err := j.Instance.Requester.SetCrumb(j.Context, ar); err != nil {
+		return "", nil, err
+	}
+	ar.SetHeader("Content-Type", "application/x-www-form-urlencoded")
+	ar.Suffix = ""
+
+	var text string
+	resp, err := j.Instance.Requester.Do(j.Context, ar, &text, query)
+	return text, resp, err
+}
+
+// checkStatus will convert non 2xx responses into an error
+//
+// Args:
+//	resp - http response
+//	endpoint - endpoint used in the error message
+//
+// Returns
+//	nil or error
+func checkStatus(resp *http.Response, endpoint string) error {
+	if resp == nil {
+		return fmt.Errorf("no response from %s", endpoint)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned status code: %d", endpoint, resp.StatusCode)
+	}
+	return nil
+}
+
+// jobBase returns the url path of a job or folder full name
+//
+// Args:
+//	fullName - job full name, i.e: folder/subfolder/job
+//
+// Returns
+//	string, i.e: /job/folder/job/subfolder/job/job
+func jobBase(fullName string) string {
+	var base strings.Builder
+	for _, name := range strings.Split(strings.Trim(fullName, "/"), "/") {
+		if name != "" {
+			base.WriteString("/job/" + url.PathEscape(name))
+		}
+	}
+	return base.String()
+}
+
+// buildBase returns the url path of a build
+//
+// Args:
+//	fullName - job full name, i.e: folder/job
+//	build - build number or permalink, i.e: 42 or lastBuild
+//
+// Returns
+//	string, i.e: /job/folder/job/job/42
+func buildBase(fullName string, build string) string {
+	if build == "" {
+		build = "lastBuild"
+	}
+	return jobBase(fullName) + "/" + url.PathEscape(build)
+}
@@ -0,0 +1,360 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pollInterval is the time between requests when waiting for the queue
+// or a build
+const pollInterval = 2 * time.Second
+
+// BuildParameter is a parameter value of a build
+type BuildParameter struct {
+	Class string      `json:"_class"`
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// sleep waits for d or until the context is cancelled
+func (j *Jenkins) sleep(d time.Duration) error {
+	select {
+	case <-j.Context.Done():
+		return j.Context.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// GetBuildParameters will get the parameters of a build from its
+// ParametersAction
+//
+// Args:
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//
+// Returns
+//	list of parameters, nil or error
+func (j *Jenkins) GetBuildParameters(jobName string, build string) ([]BuildParameter, error) {
+	response := struct {
+		Actions []struct {
+			Class      string           `json:"_class"`
+			Parameters []BuildParameter `json:"parameters"`
+		} `json:"actions"`
+	}{}
+	err := j.getJSON(buildBase(jobName, build), &response, map[string]string{"tree": "actions[_class,parameters[_class,name,value]]"})
+	if err != nil {
+		return nil, err
+	}
+	for _, action := range response.Actions {
+		if action.Class == "hudson.model.ParametersAction" {
+			return action.Parameters, nil
+		}
+	}
+	return nil, nil
+}
+
+// triggerBuild will add a job to the build queue
+//
+// Args:
+//	jobName - job full name
+//	params - build parameters, nil for jobs without parameters
+//
+// Returns
+//	queue item id, nil or error
+func (j *Jenkins) triggerBuild(jobName string, params url.Values) (int64, error) {
+	endpoint := jobBase(jobName) + "/build"
+	if params != nil {
+		endpoint = jobBase(jobName) + "/buildWithParameters"
+	}
+	resp, err := j.Instance.Requester.Post(j.Context, endpoint, strings.NewReader(params.Encode()), nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	if err = checkStatus(resp, endpoint); err != nil {
+		return 0, err
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || !strings.Contains(location.Path, "/queue/item/") {
+		return 0, fmt.Errorf("%s did not return the queue item location", endpoint)
+	}
+	return strconv.ParseInt(path.Base(location.Path), 10, 64)
+}
+
+// RebuildJob will start a job again with the parameters of a build,
+// password and file parameters are not exposed by the API and use the
+// default value
+//
+// Args:
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//
+// Returns
+//	queue item id, names of the parameters skipped, nil or error
+func (j *Jenkins) RebuildJob(jobName string, build string) (int64, []string, error) {
+	parameters, err := j.GetBuildParameters(jobName, build)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var params url.Values
+	var skipped []string
+	if parameters != nil {
+		params = url.Values{}
+		for _, p := range parameters {
+			if p.Value == nil {
+				skipped = append(skipped, p.Name)
+				continue
+			}
+			params.Set(p.Name, fmt.Sprint(p.Value))
+		}
+	}
+
+	queueID, err := j.triggerBuild(jobName, params)
+	return queueID, skipped, err
+}
+
+// replayCauseClass is the cause of the builds started by the replay action
+const replayCauseClass = "org.jenkinsci.plugins.workflow.cps.replay.ReplayCause"
+
+// replayStartTimeout is how long ReplayBuild waits for the replayed build
+// to be created
+const replayStartTimeout = 5 * time.Minute
+
+// ReplayBuild will run a pipeline build again with a changed main
+// script, using the replay action. The action does not return the queue
+// item, the new build is the first one after the replay with a ReplayCause
+// of the original build, it waits until the build is created or
+// replayStartTimeout
+//
+// Args:
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//	script - new Jenkinsfile
+//
+// Returns
+//	number of the new build, nil or error
+func (j *Jenkins) ReplayBuild(jobName string, build string, script string) (int64, error) {
+	source := struct {
+		Number int64 `json:"number"`
+	}{}
+	err := j.getJSON(buildBase(jobName, build), &source, map[string]string{"tree": "number"})
+	if err != nil {
+		return 0, err
+	}
+	job := struct {
+		NextBuildNumber int64 `json:"nextBuildNumber"`
+	}{}
+	err = j.getJSON(jobBase(jobName), &job, map[string]string{"tree": "nextBuildNumber"})
+	if err != nil {
+		return 0, err
+	}
+
+	// the replay form is read with getSubmittedForm, it must be sent as
+	// json, the loaded scripts not in the form keep the original content
+	form, err := json.Marshal(map[string]string{"mainScript": script})
+	if err != nil {
+		return 0, err
+	}
+	endpoint := buildBase(jobName, build) + "/replay/run"
+	_, resp, err := j.postText(endpoint, strings.NewReader(url.Values{"json": {string(form)}}.Encode()), nil)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode == 404 {
+		return 0, fmt.Errorf("build %s of %s cannot be replayed, is it a pipeline build?", build, jobName)
+	}
+	if err = checkStatus(resp, endpoint); err != nil {
+		return 0, err
+	}
+	return j.waitForReplay(jobName, source.Number, job.NextBuildNumber)
+}
+
+// waitForReplay will wait for the build started by a replay, other builds
+// started at the same time are skipped by checking the ReplayCause
+//
+// Args:
+//	jobName - job full name
+//	original - number of the replayed build
+//	first - next build number before the replay
+//
+// Returns
+//	number of the new build, nil or error
+func (j *Jenkins) waitForReplay(jobName string, original int64, first int64) (int64, error) {
+	deadline := time.Now().Add(replayStartTimeout)
+	for {
+		job := struct {
+			Builds []struct {
+				Number  int64 `json:"number"`
+				Actions []struct {
+					Causes []struct {
+						Class          string `json:"_class"`
+						OriginalNumber int64  `json:"originalNumber"`
+					} `json:"causes"`
+				} `json:"actions"`
+			} `json:"builds"`
+		}{}
+		tree := "builds[number,actions[causes[_class,originalNumber]]]{0,20}"
+		err := j.getJSON(jobBase(jobName), &job, map[string]string{"tree": tree})
+		if err != nil {
+			return 0, err
+		}
+
+		// builds are newest first, the oldest match is the replay
+		number := int64(0)
+		for _, b := range job.Builds {
+			if b.Number < first {
+				break
+			}
+			for _, a := range b.Actions {
+				for _, c := range a.Causes {
+					if c.Class == replayCauseClass && c.OriginalNumber == original {
+						number = b.Number
+					}
+				}
+			}
+		}
+		if number > 0 {
+			return number, nil
+		}
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("the replay of %s #%d did not start after %s", jobName, original, replayStartTimeout)
+		}
+		if err = j.sleep(pollInterval); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// WaitForQueueItem will wait until a queue item starts to build
+//
+// Args:
+//	queueID - queue item id
+//
+// Returns
+//	build number, nil or error
+func (j *Jenkins) WaitForQueueItem(queueID int64) (int64, error) {
+	endpoint := "/queue/item/" + strconv.FormatInt(queueID, 10)
+	for {
+		item := struct {
+			Cancelled  bool   `json:"cancelled"`
+			Why        string `json:"why"`
+			Executable *struct {
+				Number int64 `json:"number"`
+			} `json:"executable"`
+		}{}
+		err := j.getJSON(endpoint, &item, map[string]string{"tree": "cancelled,why,executable[number]"})
+		if err != nil {
+			return 0, err
+		}
+		if item.Cancelled {
+			return 0, fmt.Errorf("queue item %d was cancelled", queueID)
+		}
+		if item.Executable != nil && item.Executable.Number > 0 {
+			return item.Executable.Number, nil
+		}
+		if err = j.sleep(pollInterval); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// buildStatus returns if a build is running and its result, the build
+// not existing yet is reported as running
+func (j *Jenkins) buildStatus(jobName string, number int64) (bool, string, error) {
+	endpoint := buildBase(jobName, strconv.FormatInt(number, 10))
+	build := struct {
+		Building bool   `json:"building"`
+		Result   string `json:"result"`
+	}{}
+	resp, err := j.Instance.Requester.GetJSON(j.Context, endpoint, &build, map[string]string{"tree": "building,result"})
+	if err != nil {
+		return false, "", err
+	}
+	if resp.StatusCode == 404 {
+		return true, "", nil
+	}
+	if err = checkStatus(resp, endpoint); err != nil {
+		return false, "", err
+	}
+	return build.Building || build.Result == "", build.Result, nil
+}
+
+// WaitForBuild will wait until a build finishes, the console log is
+// written to out while the build runs when out is not nil
+//
+// Args:
+//	out - where the console log is written, nil to only wait
+//	jobName - job full name
+//	number - build number
+//
+// Returns
+//	build result, i.e: SUCCESS, nil or error
+func (j *Jenkins) WaitForBuild(out io.Writer, jobName string, number int64) (string, error) {
+	endpoint := buildBase(jobName, strconv.FormatInt(number, 10)) + "/logText/progressiveText"
+	var start int64
+	for {
+		running, result, err := j.buildStatus(jobName, number)
+		if err != nil {
+			return "", err
+		}
+
+		if out != nil {
+			// the last read after the build finished gets the end of the log
+			if start, _, err = j.readProgressiveLog(out, endpoint, start); err != nil {
+				return "", err
+			}
+		}
+
+		if !running {
+			return result, nil
+		}
+		if err = j.sleep(pollInterval); err != nil {
+			return "", err
+		}
+	}
+}
+
+// readProgressiveLog will write the log from start until there is no new
+// data, it works with any logText/progressiveText endpoint
+//
+// Args:
+//	out - where the log is written
+//	endpoint - progressiveText endpoint
+//	start - offset already written
+//
+// Returns
+//	new offset, true when the server has more data coming, nil or error
+func (j *Jenkins) readProgressiveLog(out io.Writer, endpoint string, start int64) (int64, bool, error) {
+	for {
+		var text string
+		resp, err := j.Instance.Requester.Get(j.Context, endpoint, &text, map[string]string{"start": strconv.FormatInt(start, 10)})
+		if err != nil {
+			return start, false, err
+		}
+		if resp.StatusCode == 404 {
+			return start, false, nil
+		}
+		if err = checkStatus(resp, endpoint); err != nil {
+			return start, false, err
+		}
+		fmt.Fprint(out, text)
+
+		more := resp.Header.Get("X-More-Data") != ""
+		size, err := strconv.ParseInt(resp.Header.Get("X-Text-Size"), 10, 64)
+		if err != nil {
+			return start, more, nil
+		}
+		if size == start || !more {
+			return size, more, nil
+		}
+		start = size
+	}
+}
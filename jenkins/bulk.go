@@ -0,0 +1,229 @@
+package jenkins
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bndr/gojenkins"
+)
+
+// JobSelector selects jobs by name, folder, view, status color and
+// last build time. Empty fields match everything
+type JobSelector struct {
+	Name         *regexp.Regexp
+	Folder       string
+	View         string
+	Color        string
+	NotBuiltDays int
+}
+
+// selectorSeparator matches the commas followed by a selector key, other
+// commas are part of the value, i.e: name=^app-[0-9]{1,3}$
+var selectorSeparator = regexp.MustCompile(`,\s*(name|folder|view|color|notbuilt)=`)
+
+// splitSelector splits a selector on the commas followed by a known key
+func splitSelector(selector string) []string {
+	var items []string
+	start := 0
+	for _, m := range selectorSeparator.FindAllStringIndex(selector, -1) {
+		items = append(items, selector[start:m[0]])
+		start = m[0] + 1
+	}
+	return append(items, selector[start:])
+}
+
+// ParseSelector will parse a selector like:
+//	name=^app-,folder=team,view=ci,color=red,notbuilt=30d
+//
+// Args:
+//	selector - comma separated key=value list
+//
+// Returns
+//	job selector, nil or error
+func ParseSelector(selector string) (*JobSelector, error) {
+	s := &JobSelector{}
+	if strings.TrimSpace(selector) == "" {
+		return nil, errors.New("empty selector")
+	}
+	for _, item := range splitSelector(selector) {
+		kv := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, fmt.Errorf("invalid selector: %s (use key=value)", item)
+		}
+		key, value := kv[0], kv[1]
+		switch key {
+		case "name":
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid name regex: %s", err)
+			}
+			s.Name = re
+		case "folder":
+			s.Folder = strings.Trim(value, "/")
+		case "view":
+			s.View = value
+		case "color":
+			s.Color = value
+		case "notbuilt":
+			days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+			if err != nil || days < 0 {
+				return nil, fmt.Errorf("invalid notbuilt days: %s", value)
+			}
+			s.NotBuiltDays = days
+		default:
+			return nil, fmt.Errorf("unknown selector key: %s (use name, folder, view, color or notbuilt)", key)
+		}
+	}
+	return s, nil
+}
+
+// SelectJobs will list the jobs matching a selector
+//
+// Args:
+//	selector - job selector
+//
+// Returns
+//	list of jobs, nil or error
+func (j *Jenkins) SelectJobs(selector *JobSelector) ([]*JobInfo, error) {
+	jobs, err := j.ListJobs(selector.Folder, "lastBuild[number,result,timestamp]")
+	if err != nil {
+		return nil, err
+	}
+
+	var members map[string]bool
+	if selector.View != "" {
+		view, err := j.getView(selector.Folder, selector.View)
+		if err != nil {
+			return nil, err
+		}
+		response := struct {
+			Jobs []struct {
+				FullName string `json:"fullName"`
+			} `json:"jobs"`
+		}{}
+		err = j.getJSON(view.Base, &response, map[string]string{"tree": "jobs[fullName]"})
+		if err != nil {
+			return nil, err
+		}
+		members = map[string]bool{}
+		for _, job := range response.Jobs {
+			members[job.FullName] = true
+		}
+	}
+
+	limit := time.Now().AddDate(0, 0, -selector.NotBuiltDays)
+	var selected []*JobInfo
+	for _, job := range jobs {
+		if selector.Name != nil && !selector.Name.MatchString(job.FullName) {
+			continue
+		}
+		if members != nil && !members[job.FullName] {
+			continue
+		}
+		if selector.Color != "" && strings.TrimSuffix(job.Color, "_anime") != selector.Color {
+			continue
+		}
+		if selector.NotBuiltDays > 0 && job.LastBuild != nil && job.LastBuildTime().After(limit) {
+			continue
+		}
+		selected = append(selected, job)
+	}
+	return selected, nil
+}
+
+// BulkResult is the result of a bulk action for a single job
+type BulkResult struct {
+	Job string
+	Err error
+}
+
+// BulkJobAction will enable, disable or delete jobs concurrently
+//
+// Args:
+//	jobNames - job full names
+//	action - enable, disable or delete
+//	workers - max number of concurrent requests
+//
+// Returns
+//	result for each job, nil or error
+func (j *Jenkins) BulkJobAction(jobNames []string, action string, workers int) ([]BulkResult, error) {
+	var do func(job *gojenkins.Job) (bool, error)
+	switch action {
+	case "enable":
+		do = func(job *gojenkins.Job) (bool, error) { return job.Enable(j.Context) }
+	case "disable":
+		do = func(job *gojenkins.Job) (bool, error) { return job.Disable(j.Context) }
+	case "delete":
+		do = func(job *gojenkins.Job) (bool, error) { return job.Delete(j.Context) }
+	default:
+		return nil, fmt.Errorf("unknown action: %s", action)
+	}
+
+	results := make([]BulkResult, len(jobNames))
+	runParallel(len(jobNames), workers, func(i int) {
+		job := &gojenkins.Job{Jenkins: j.Instance, Raw: new(gojenkins.JobResponse), Base: jobBase(jobNames[i])}
+		_, err := do(job)
+		results[i] = BulkResult{Job: jobNames[i], Err: err}
+	})
+	return results, nil
+}
+
+// ReadJobList will read job full names from a file, it accepts the json
+// and csv reports from analyze (job field/column) or one job per line
+//
+// Args:
+//	path - path to the file, - reads from stdin
+//
+// Returns
+//	list of job full names, nil or error
+func ReadJobList(path string) ([]string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	text := strings.TrimSpace(string(data))
+	switch {
+	case strings.HasPrefix(text, "["):
+		var report []struct {
+			Job string `json:"job"`
+		}
+		if err = json.Unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("invalid json report: %s", err)
+		}
+		for _, r := range report {
+			names = append(names, r.Job)
+		}
+	case strings.HasPrefix(text, "job,"):
+		records, err := csv.NewReader(strings.NewReader(text)).ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv report: %s", err)
+		}
+		for _, r := range records[1:] {
+			names = append(names, r[0])
+		}
+	default:
+		for _, line := range strings.Split(text, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				names = append(names, line)
+			}
+		}
+	}
+	return names, nil
+}
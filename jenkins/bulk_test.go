@@ -0,0 +1,34 @@
+package jenkins
+
+import "testing"
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		name     string
+		folder   string
+		wantErr  bool
+	}{
+		{selector: "name=^app-[0-9]{1,3}$,folder=team", name: "^app-[0-9]{1,3}$", folder: "team"},
+		{selector: "folder=team/, name=a,b", name: "a,b", folder: "team"},
+		{selector: "name=(", wantErr: true},
+		{selector: "owner=me", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		s, err := ParseSelector(tt.selector)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.selector)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.selector, err)
+			continue
+		}
+		if s.Name.String() != tt.name || s.Folder != tt.folder {
+			t.Errorf("%s: name %q folder %q, want %q and %q", tt.selector, s.Name, s.Folder, tt.name, tt.folder)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Names of the lists kept in the cache
+const (
+	CacheJobs    = "jobs"
+	CacheNodes   = "nodes"
+	CacheViews   = "views"
+	CachePlugins = "plugins"
+)
+
+// DefaultCacheTTL is how long the cached lists are used before they are
+// collected again from the server
+const DefaultCacheTTL = 10 * time.Minute
+
+// unsafeContextChars are replaced in the context directory names
+var unsafeContextChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// Cache is an on-disk cache of job, node, view and plugin names for a
+// context, the context is the server and user of the config file
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// cacheEntry is a cached list with the time it was collected
+type cacheEntry struct {
+	Updated time.Time `json:"updated"`
+	Items   []string  `json:"items"`
+}
+
+// cacheRoot returns the directory with the cache of all contexts
+func cacheRoot() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jenkinsctl"), nil
+}
+
+// NewCache will return the cache of the context of a config
+//
+// Args:
+//	config - configuration with the server and user
+//	ttl - how long a cached list is valid
+//
+// Returns
+//	cache, nil or error
+func NewCache(config Config, ttl time.Duration) (*Cache, error) {
+	root, err := cacheRoot()
+	if err != nil {
+		return nil, err
+	}
+	server := config.Server
+	if u, err := url.Parse(config.Server); err == nil && u.Host != "" {
+		server = u.Host + u.Path
+	}
+	name := unsafeContextChars.ReplaceAllString(server+"_"+config.JenkinsUser, "_")
+	return &Cache{Dir: filepath.Join(root, strings.Trim(name, "_")), TTL: ttl}, nil
+}
+
+// path returns the file of a cached list, the name is escaped so lists
+// like views/folder are kept in a single directory
+func (c *Cache) path(name string) string {
+	return filepath.Join(c.Dir, url.PathEscape(name)+".json")
+}
+
+// Get will read a cached list
+//
+// Args:
+//	name - list name, i.e: CacheJobs
+//
+// Returns
+//	items, true when the list is cached and not expired
+func (c *Cache) Get(name string) ([]string, bool) {
+	data, err := ioutil.ReadFile(c.path(name))
+	if err != nil {
+		return nil, false
+	}
+	entry := cacheEntry{}
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if time.Since(entry.Updated) > c.TTL {
+		return nil, false
+	}
+	return entry.Items, true
+}
+
+// Put will write a list to the cache
+//
+// Args:
+//	name - list name, i.e: CacheJobs
+//	items - list to cache
+//
+// Returns
+//	nil or error
+func (c *Cache) Put(name string, items []string) error {
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(cacheEntry{Updated: time.Now(), Items: items})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path(name), data, 0600)
+}
+
+// Delete will remove a cached list, it is used after a change on the
+// server so the next completion collects the list again
+//
+// Args:
+//	name - list name, i.e: CacheJobs
+//
+// Returns
+//	nil or error
+func (c *Cache) Delete(name string) error {
+	err := os.Remove(c.path(name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Clear will remove the cached lists of the context
+//
+// Args:
+//
+// Returns
+//	nil or error
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.Dir)
+}
+
+// ClearAllCaches will remove the cached lists of all contexts
+//
+// Args:
+//
+// Returns
+//	nil or error
+func ClearAllCaches() error {
+	root, err := cacheRoot()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(root)
+}
+
+// CachedNames returns a list of names from the cache, when it is missing or
+// expired the list is collected from the server and cached
+//
+// Args:
+//	cache - cache of the context, nil to always use the server
+//	name - list name, i.e: CacheJobs
+//	fetch - collects the list from the server
+//
+// Returns
+//	list of names, nil or error
+func CachedNames(cache *Cache, name string, fetch func() ([]string, error)) ([]string, error) {
+	if cache != nil {
+		if items, ok := cache.Get(name); ok {
+			return items, nil
+		}
+	}
+	items, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	// the list is still valid when the cache cannot be written, i.e: a
+	// read-only home, it is collected again the next time
+	if cache != nil {
+		_ = cache.Put(name, items)
+	}
+	return items, nil
+}
+
+// JobNames will collect the full names of all jobs and folders with a
+// single request, and one more per folder deeper than jobTreeDepth.
+// Folders end with /
+//
+// Args:
+//
+// Returns
+//	list of names, nil or error
+func (j *Jenkins) JobNames() ([]string, error) {
+	items, err := j.getJobTree("", "_class,fullName")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	var walk func(items []*JobInfo)
+	walk = func(items []*JobInfo) {
+		for _, item := range items {
+			if item.IsFolder() {
+				names = append(names, item.FullName+"/")
+				walk(item.Jobs)
+				continue
+			}
+			names = append(names, item.FullName)
+		}
+	}
+	walk(items)
+	sort.Strings(names)
+	return names, nil
+}
+
+// NodeNames will collect the names of all nodes with a single request
+//
+// Args:
+//
+// Returns
+//	list of names, nil or error
+func (j *Jenkins) NodeNames() ([]string, error) {
+	nodes, err := j.GetNodeStatus()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.DisplayName)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ViewNames will collect the names of the views of the root or of a
+// folder with a single request
+//
+// Args:
+//	folder - folder full name, empty for the root
+//
+// Returns
+//	list of names, nil or error
+func (j *Jenkins) ViewNames(folder string) ([]string, error) {
+	endpoint := jobBase(folder)
+	if endpoint == "" {
+		endpoint = "/"
+	}
+	response := struct {
+		Views []struct {
+			Name string `json:"name"`
+		} `json:"views"`
+	}{}
+	err := j.getJSON(endpoint, &response, map[string]string{"tree": "views[name]"})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(response.Views))
+	for _, v := range response.Views {
+		names = append(names, v.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// PluginNames will collect the short names of the installed plugins with
+// a single request
+//
+// Args:
+//
+// Returns
+//	list of names, nil or error
+func (j *Jenkins) PluginNames() ([]string, error) {
+	response := struct {
+		Plugins []struct {
+			ShortName string `json:"shortName"`
+		} `json:"plugins"`
+	}{}
+	err := j.getJSON("/pluginManager", &response, map[string]string{"tree": "plugins[shortName]"})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(response.Plugins))
+	for _, p := range response.Plugins {
+		names = append(names, p.ShortName)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,383 @@
+package jenkins
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Check status, they match the Nagios plugin exit codes
+const (
+	StatusOK       = 0
+	StatusWarning  = 1
+	StatusCritical = 2
+	StatusUnknown  = 3
+)
+
+var statusNames = []string{"OK", "WARNING", "CRITICAL", "UNKNOWN"}
+
+// monitorTree is the tree= query used to collect the node monitors
+const monitorTree = "computer[displayName,offline,temporarilyOffline,offlineCauseReason,monitorData[*[*]]]"
+
+// spaceMonitor is the data of DiskSpaceMonitor and TemporarySpaceMonitor
+type spaceMonitor struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// NodeMonitor is the health data of a node
+type NodeMonitor struct {
+	DisplayName        string `json:"displayName"`
+	Offline            bool   `json:"offline"`
+	TemporarilyOffline bool   `json:"temporarilyOffline"`
+	OfflineCauseReason string `json:"offlineCauseReason"`
+	MonitorData        struct {
+		DiskSpace *spaceMonitor `json:"hudson.node_monitors.DiskSpaceMonitor"`
+		TempSpace *spaceMonitor `json:"hudson.node_monitors.TemporarySpaceMonitor"`
+		SwapSpace *struct {
+			AvailableSwapSpace      int64 `json:"availableSwapSpace"`
+			TotalSwapSpace          int64 `json:"totalSwapSpace"`
+			AvailablePhysicalMemory int64 `json:"availablePhysicalMemory"`
+			TotalPhysicalMemory     int64 `json:"totalPhysicalMemory"`
+		} `json:"hudson.node_monitors.SwapSpaceMonitor"`
+		Clock *struct {
+			Diff int64 `json:"diff"`
+		} `json:"hudson.node_monitors.ClockMonitor"`
+		ResponseTime *struct {
+			Average int64 `json:"average"`
+		} `json:"hudson.node_monitors.ResponseTimeMonitor"`
+	} `json:"monitorData"`
+}
+
+// NodeThresholds are the limits used by CheckNodes, zero disables a limit
+type NodeThresholds struct {
+	OfflineWarning   int
+	OfflineCritical  int
+	DiskWarning      int64
+	DiskCritical     int64
+	SwapWarning      int64
+	SwapCritical     int64
+	TempWarning      int64
+	TempCritical     int64
+	ClockWarning     time.Duration
+	ClockCritical    time.Duration
+	ResponseWarning  time.Duration
+	ResponseCritical time.Duration
+}
+
+// nodeCheck is the result of the checks for a single node
+type nodeCheck struct {
+	node     *NodeMonitor
+	status   int
+	problems []string
+}
+
+func (c *nodeCheck) raise(status int, problem string) {
+	if status > c.status {
+		c.status = status
+	}
+	c.problems = append(c.problems, problem)
+}
+
+// checkBelow raises the check status when the free value is lower than
+// the warning or critical threshold
+func (c *nodeCheck) checkBelow(name string, value int64, warning int64, critical int64) {
+	switch {
+	case critical > 0 && value < critical:
+		c.raise(StatusCritical, fmt.Sprintf("%s %s free %s < %s", c.node.DisplayName, name, FormatSize(value), FormatSize(critical)))
+	case warning > 0 && value < warning:
+		c.raise(StatusWarning, fmt.Sprintf("%s %s free %s < %s", c.node.DisplayName, name, FormatSize(value), FormatSize(warning)))
+	}
+}
+
+// checkAbove raises the check status when the value is greater than
+// the warning or critical threshold
+func (c *nodeCheck) checkAbove(name string, value time.Duration, warning time.Duration, critical time.Duration) {
+	if value < 0 {
+		value = -value
+	}
+	switch {
+	case critical > 0 && value >= critical:
+		c.raise(StatusCritical, fmt.Sprintf("%s %s %s >= %s", c.node.DisplayName, name, value, critical))
+	case warning > 0 && value >= warning:
+		c.raise(StatusWarning, fmt.Sprintf("%s %s %s >= %s", c.node.DisplayName, name, value, warning))
+	}
+}
+
+// GetNodeMonitors will collect the monitor data from all nodes
+//
+// Returns
+//	list of node monitors, nil or error
+func (j *Jenkins) GetNodeMonitors() ([]*NodeMonitor, error) {
+	computers := struct {
+		Computers []*NodeMonitor `json:"computer"`
+	}{}
+	err := j.getJSON("/computer", &computers, map[string]string{"tree": monitorTree})
+	if err != nil {
+		return nil, err
+	}
+	return computers.Computers, nil
+}
+
+// CheckNodes will validate the nodes health against the thresholds and
+// write the result in Nagios plugin or Prometheus textfile format
+//
+// Args:
+//	thresholds - limits to validate
+//	format - nagios or prometheus
+//	out - where the result is written
+//
+// Returns
+//	check status (StatusOK, StatusWarning, StatusCritical), nil or error
+func (j *Jenkins) CheckNodes(thresholds NodeThresholds, format string, out io.Writer) (int, error) {
+	if format != "nagios" && format != "prometheus" {
+		return StatusUnknown, fmt.Errorf("unknown format: %s (use nagios or prometheus)", format)
+	}
+
+	nodes, err := j.GetNodeMonitors()
+	if err != nil {
+		return StatusUnknown, err
+	}
+
+	status := StatusOK
+	offline := 0
+	var problems []string
+	checks := make([]*nodeCheck, len(nodes))
+	for i, node := range nodes {
+		c := &nodeCheck{node: node}
+		checks[i] = c
+		if node.Offline || node.TemporarilyOffline {
+			offline++
+			problems = append(problems, fmt.Sprintf("%s offline %s", node.DisplayName, node.OfflineCauseReason))
+			continue
+		}
+
+		data := node.MonitorData
+		if data.DiskSpace != nil {
+			c.checkBelow("disk", data.DiskSpace.Size, thresholds.DiskWarning, thresholds.DiskCritical)
+		}
+		if data.SwapSpace != nil {
+			c.checkBelow("swap", data.SwapSpace.AvailableSwapSpace, thresholds.SwapWarning, thresholds.SwapCritical)
+		}
+		if data.TempSpace != nil {
+			c.checkBelow("temp", data.TempSpace.Size, thresholds.TempWarning, thresholds.TempCritical)
+		}
+		if data.Clock != nil {
+			c.checkAbove("clock difference", time.Duration(data.Clock.Diff)*time.Millisecond, thresholds.ClockWarning, thresholds.ClockCritical)
+		}
+		if data.ResponseTime != nil {
+			c.checkAbove("response time", time.Duration(data.ResponseTime.Average)*time.Millisecond, thresholds.ResponseWarning, thresholds.ResponseCritical)
+		}
+		if c.status > status {
+			status = c.status
+		}
+		problems = append(problems, c.problems...)
+	}
+
+	switch {
+	case thresholds.OfflineCritical > 0 && offline >= thresholds.OfflineCritical:
+		status = StatusCritical
+	case thresholds.OfflineWarning > 0 && offline >= thresholds.OfflineWarning && status < StatusWarning:
+		status = StatusWarning
+	}
+
+	if format == "prometheus" {
+		writePrometheus(out, checks, offline, status)
+	} else {
+		writeNagios(out, checks, thresholds, offline, status, problems)
+	}
+	return status, nil
+}
+
+// writeNagios will write the check result using the Nagios plugin format:
+// STATUS - summary | perfdata followed by one problem per line
+func writeNagios(out io.Writer, checks []*nodeCheck, thresholds NodeThresholds, offline int, status int, problems []string) {
+	perfdata := []string{
+		fmt.Sprintf("offline=%d;%s;%s;0;%d", offline, threshold(int64(thresholds.OfflineWarning)), threshold(int64(thresholds.OfflineCritical)), len(checks)),
+	}
+	for _, c := range checks {
+		data := c.node.MonitorData
+		if data.DiskSpace != nil {
+			perfdata = append(perfdata, perfLabel(c.node.DisplayName, "disk", data.DiskSpace.Size, "B", thresholds.DiskWarning, thresholds.DiskCritical))
+		}
+		if data.SwapSpace != nil {
+			perfdata = append(perfdata, perfLabel(c.node.DisplayName, "swap", data.SwapSpace.AvailableSwapSpace, "B", thresholds.SwapWarning, thresholds.SwapCritical))
+		}
+		if data.TempSpace != nil {
+			perfdata = append(perfdata, perfLabel(c.node.DisplayName, "temp", data.TempSpace.Size, "B", thresholds.TempWarning, thresholds.TempCritical))
+		}
+		if data.Clock != nil {
+			perfdata = append(perfdata, perfLabel(c.node.DisplayName, "clock", data.Clock.Diff, "ms", thresholds.ClockWarning.Milliseconds(), thresholds.ClockCritical.Milliseconds()))
+		}
+		if data.ResponseTime != nil {
+			perfdata = append(perfdata, perfLabel(c.node.DisplayName, "response", data.ResponseTime.Average, "ms", thresholds.ResponseWarning.Milliseconds(), thresholds.ResponseCritical.Milliseconds()))
+		}
+	}
+
+	fmt.Fprintf(out, "NODES %s - %d node(s), %d offline | %s\n",
+		statusNames[status], len(checks), offline, strings.Join(perfdata, " "))
+	for _, p := range problems {
+		fmt.Fprintf(out, "%s\n", strings.TrimSpace(p))
+	}
+}
+
+// threshold formats a perfdata threshold, empty when disabled
+func threshold(value int64) string {
+	if value <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+// perfLabel formats a single perfdata entry: 'node_name'=valueUOM;warn;crit
+func perfLabel(node string, name string, value int64, uom string, warning int64, critical int64) string {
+	label := strings.Replace(node+"_"+name, "'", "", -1)
+	return fmt.Sprintf("'%s'=%d%s;%s;%s", label, value, uom, threshold(warning), threshold(critical))
+}
+
+// writePrometheus will write the check result using the Prometheus
+// textfile collector format
+func writePrometheus(out io.Writer, checks []*nodeCheck, offline int, status int) {
+	type metric struct {
+		name  string
+		help  string
+		value func(n *NodeMonitor) (float64, bool)
+	}
+	metrics := []metric{
+		{"jenkins_node_offline", "Node is offline (1) or online (0)", func(n *NodeMonitor) (float64, bool) {
+			if n.Offline || n.TemporarilyOffline {
+				return 1, true
+			}
+			return 0, true
+		}},
+		{"jenkins_node_disk_free_bytes", "Free disk space in the node workspace", func(n *NodeMonitor) (float64, bool) {
+			if n.MonitorData.DiskSpace == nil {
+				return 0, false
+			}
+			return float64(n.MonitorData.DiskSpace.Size), true
+		}},
+		{"jenkins_node_swap_free_bytes", "Free swap space in the node", func(n *NodeMonitor) (float64, bool) {
+			if n.MonitorData.SwapSpace == nil {
+				return 0, false
+			}
+			return float64(n.MonitorData.SwapSpace.AvailableSwapSpace), true
+		}},
+		{"jenkins_node_temp_free_bytes", "Free space in the node temporary directory", func(n *NodeMonitor) (float64, bool) {
+			if n.MonitorData.TempSpace == nil {
+				return 0, false
+			}
+			return float64(n.MonitorData.TempSpace.Size), true
+		}},
+		{"jenkins_node_clock_difference_seconds", "Clock difference between the node and the controller", func(n *NodeMonitor) (float64, bool) {
+			if n.MonitorData.Clock == nil {
+				return 0, false
+			}
+			return float64(n.MonitorData.Clock.Diff) / 1000, true
+		}},
+		{"jenkins_node_response_time_seconds", "Average response time of the node", func(n *NodeMonitor) (float64, bool) {
+			if n.MonitorData.ResponseTime == nil {
+				return 0, false
+			}
+			return float64(n.MonitorData.ResponseTime.Average) / 1000, true
+		}},
+	}
+
+	for _, m := range metrics {
+		fmt.Fprintf(out, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(out, "# TYPE %s gauge\n", m.name)
+		for _, c := range checks {
+			if value, ok := m.value(c.node); ok {
+				fmt.Fprintf(out, "%s{node=%q} %g\n", m.name, c.node.DisplayName, value)
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "# HELP jenkins_node_check_status Node check status (0 ok, 1 warning, 2 critical)\n")
+	fmt.Fprintf(out, "# TYPE jenkins_node_check_status gauge\n")
+	for _, c := range checks {
+		fmt.Fprintf(out, "jenkins_node_check_status{node=%q} %d\n", c.node.DisplayName, c.status)
+	}
+	fmt.Fprintf(out, "# HELP jenkins_nodes_offline Number of offline nodes\n")
+	fmt.Fprintf(out, "# TYPE jenkins_nodes_offline gauge\n")
+	fmt.Fprintf(out, "jenkins_nodes_offline %d\n", offline)
+	fmt.Fprintf(out, "# HELP jenkins_nodes_check_status Overall node check status (0 ok, 1 warning, 2 critical, 3 unknown)\n")
+	fmt.Fprintf(out, "# TYPE jenkins_nodes_check_status gauge\n")
+	fmt.Fprintf(out, "jenkins_nodes_check_status %d\n", status)
+	fmt.Fprintf(out, "# HELP jenkins_up Jenkins answered the node check (1) or not (0)\n")
+	fmt.Fprintf(out, "# TYPE jenkins_up gauge\n")
+	fmt.Fprintf(out, "jenkins_up 1\n")
+}
+
+// WriteCheckUnknown will write an UNKNOWN check result, used when the
+// server cannot be reached. The Prometheus format reports jenkins_up 0 so
+// a textfile is still written
+//
+// Args:
+//	out - where the result is written
+//	format - nagios or prometheus
+//	reason - why the check could not run
+//
+// Returns
+func WriteCheckUnknown(out io.Writer, format string, reason error) {
+	if format != "prometheus" {
+		fmt.Fprintf(out, "NODES UNKNOWN - %s\n", reason)
+		return
+	}
+	fmt.Fprintf(out, "# HELP jenkins_up Jenkins answered the node check (1) or not (0)\n")
+	fmt.Fprintf(out, "# TYPE jenkins_up gauge\n")
+	fmt.Fprintf(out, "jenkins_up 0\n")
+	fmt.Fprintf(out, "# HELP jenkins_nodes_check_status Overall node check status (0 ok, 1 warning, 2 critical, 3 unknown)\n")
+	fmt.Fprintf(out, "# TYPE jenkins_nodes_check_status gauge\n")
+	fmt.Fprintf(out, "jenkins_nodes_check_status %d\n", StatusUnknown)
+}
+
+// ParseSize will convert sizes like 512MB or 10GB into bytes
+//
+// Args:
+//	size - size with optional B, KB, MB, GB or TB suffix
+//
+// Returns
+//	bytes, nil or error
+func ParseSize(size string) (int64, error) {
+	if size == "" {
+		return 0, nil
+	}
+	units := []struct {
+		suffix string
+		factor int64
+	}{
+		{"TB", 1 << 40},
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+	value := strings.ToUpper(strings.TrimSpace(size))
+	factor := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(value, u.suffix) {
+			factor = u.factor
+			value = strings.TrimSpace(strings.TrimSuffix(value, u.suffix))
+			break
+		}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size: %s", size)
+	}
+	return int64(number * float64(factor)), nil
+}
+
+// FormatSize will convert bytes into a human readable size
+func FormatSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	value := float64(size)
+	i := 0
+	for value >= 1024 && i < len(units)-1 {
+		value /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f%s", value, units[i])
+}
@@ -5,4 +5,5 @@ go 1.16
 require (
 	github.com/bndr/gojenkins v1.1.0
 	github.com/spf13/viper v1.8.0
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,312 @@
+package jenkins
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// graphColors are the fill colors of the graph nodes by status
+var graphColors = map[string]string{
+	"success":  "#8fd18f",
+	"failure":  "#f28b82",
+	"unstable": "#fdd663",
+	"aborted":  "#bdbdbd",
+	"running":  "#aecbfa",
+	"disabled": "#e0e0e0",
+	"notbuilt": "#ffffff",
+	"cause":    "#ffffff",
+}
+
+// graphNode is a job or build in a dependency graph
+type graphNode struct {
+	key    string
+	label  string
+	status string
+}
+
+// dependencyGraph is a directed graph of jobs or builds
+type dependencyGraph struct {
+	nodes []*graphNode
+	index map[string]int
+	edges map[[2]int]bool
+}
+
+func newDependencyGraph() *dependencyGraph {
+	return &dependencyGraph{index: map[string]int{}, edges: map[[2]int]bool{}}
+}
+
+// node returns the index of a node, adding it when missing
+func (g *dependencyGraph) node(key string, label string, status string) int {
+	if i, ok := g.index[key]; ok {
+		if status != "" {
+			g.nodes[i].status = status
+		}
+		return i
+	}
+	g.nodes = append(g.nodes, &graphNode{key: key, label: label, status: status})
+	g.index[key] = len(g.nodes) - 1
+	return len(g.nodes) - 1
+}
+
+// edge adds an edge between two nodes
+func (g *dependencyGraph) edge(from int, to int) {
+	g.edges[[2]int{from, to}] = true
+}
+
+// sortedEdges returns the edges in a stable order
+func (g *dependencyGraph) sortedEdges() [][2]int {
+	edges := make([][2]int, 0, len(g.edges))
+	for e := range g.edges {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(a, b int) bool {
+		if edges[a][0] != edges[b][0] {
+			return edges[a][0] < edges[b][0]
+		}
+		return edges[a][1] < edges[b][1]
+	})
+	return edges
+}
+
+// write will write the graph as Graphviz DOT or Mermaid
+func (g *dependencyGraph) write(out io.Writer, format string) error {
+	switch format {
+	case "dot":
+		fmt.Fprintf(out, "digraph jenkins {\n")
+		fmt.Fprintf(out, "  rankdir=LR;\n")
+		fmt.Fprintf(out, "  node [shape=box, style=\"rounded,filled\", fillcolor=\"#ffffff\"];\n")
+		for i, n := range g.nodes {
+			attrs := "label=" + strconv.Quote(n.label)
+			if color, ok := graphColors[n.status]; ok {
+				attrs += ", fillcolor=" + strconv.Quote(color)
+			}
+			if n.status == "cause" {
+				attrs += ", shape=note"
+			}
+			fmt.Fprintf(out, "  n%d [%s];\n", i, attrs)
+		}
+		for _, e := range g.sortedEdges() {
+			fmt.Fprintf(out, "  n%d -> n%d;\n", e[0], e[1])
+		}
+		fmt.Fprintf(out, "}\n")
+		return nil
+	case "mermaid":
+		fmt.Fprintf(out, "graph LR\n")
+		for i, n := range g.nodes {
+			label := strings.Replace(n.label, `"`, "#quot;", -1)
+			fmt.Fprintf(out, "  n%d[\"%s\"]\n", i, label)
+		}
+		for _, e := range g.sortedEdges() {
+			fmt.Fprintf(out, "  n%d --> n%d\n", e[0], e[1])
+		}
+		statuses := make([]string, 0, len(graphColors))
+		for status := range graphColors {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			var ids []string
+			for i, n := range g.nodes {
+				if n.status == status {
+					ids = append(ids, "n"+strconv.Itoa(i))
+				}
+			}
+			if len(ids) > 0 {
+				fmt.Fprintf(out, "  classDef %s fill:%s\n", status, graphColors[status])
+				fmt.Fprintf(out, "  class %s %s\n", strings.Join(ids, ","), status)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format: %s (use dot or mermaid)", format)
+}
+
+// colorStatus converts a job color into a graph status
+func colorStatus(color string) string {
+	switch {
+	case strings.HasSuffix(color, "_anime"):
+		return "running"
+	case color == "blue":
+		return "success"
+	case color == "red":
+		return "failure"
+	case color == "yellow":
+		return "unstable"
+	case color == "aborted", color == "disabled", color == "notbuilt":
+		return color
+	}
+	return ""
+}
+
+// resultStatus converts a build result into a graph status
+func resultStatus(result string, building bool) string {
+	if building {
+		return "running"
+	}
+	switch result {
+	case "SUCCESS":
+		return "success"
+	case "FAILURE":
+		return "failure"
+	case "UNSTABLE":
+		return "unstable"
+	case "ABORTED":
+		return "aborted"
+	}
+	return "notbuilt"
+}
+
+// GraphJobs will write the upstream/downstream dependencies of the jobs,
+// read with a single request
+//
+// Args:
+//	out - where the graph is written
+//	folder - folder full name, empty for all jobs
+//	root - only the jobs triggered by root, directly or not, empty for all
+//	format - dot or mermaid
+//
+// Returns
+//	nil or error
+func (j *Jenkins) GraphJobs(out io.Writer, folder string, root string, format string) error {
+	jobs, err := j.ListJobs(folder, "upstreamProjects[fullName,color]", "downstreamProjects[fullName,color]")
+	if err != nil {
+		return err
+	}
+
+	downstream := map[string][]JobRef{}
+	colors := map[string]string{}
+	for _, job := range jobs {
+		colors[job.FullName] = job.Color
+	}
+	// jobs outside the folder are only known by the references
+	setColor := func(ref JobRef) {
+		if _, ok := colors[ref.FullName]; !ok {
+			colors[ref.FullName] = ref.Color
+		}
+	}
+	for _, job := range jobs {
+		for _, d := range job.DownstreamProjects {
+			downstream[job.FullName] = append(downstream[job.FullName], d)
+			setColor(d)
+		}
+		for _, u := range job.UpstreamProjects {
+			downstream[u.FullName] = append(downstream[u.FullName], JobRef{FullName: job.FullName, Color: job.Color})
+			setColor(u)
+		}
+	}
+
+	g := newDependencyGraph()
+	add := func(name string) int { return g.node(name, name, colorStatus(colors[name])) }
+	if root != "" {
+		root = strings.Trim(root, "/")
+		if _, ok := colors[root]; !ok {
+			return fmt.Errorf("job %s not found", root)
+		}
+		visited := map[string]bool{root: true}
+		queue := []string{root}
+		add(root)
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+			for _, d := range downstream[name] {
+				g.edge(add(name), add(d.FullName))
+				if !visited[d.FullName] {
+					visited[d.FullName] = true
+					queue = append(queue, d.FullName)
+				}
+			}
+		}
+	} else {
+		names := make([]string, 0, len(downstream))
+		for name := range downstream {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, d := range downstream[name] {
+				g.edge(add(name), add(d.FullName))
+			}
+		}
+	}
+	return g.write(out, format)
+}
+
+// buildCauses is a build with the causes that started it
+type buildCauses struct {
+	Number   int64  `json:"number"`
+	Result   string `json:"result"`
+	Building bool   `json:"building"`
+	Actions  []struct {
+		Causes []struct {
+			Class            string `json:"_class"`
+			ShortDescription string `json:"shortDescription"`
+			UpstreamProject  string `json:"upstreamProject"`
+			UpstreamBuild    int64  `json:"upstreamBuild"`
+		} `json:"causes"`
+	} `json:"actions"`
+}
+
+// causeTree is the tree= query used to trace the cause of a build
+const causeTree = "number,result,building,actions[causes[_class,shortDescription,upstreamProject,upstreamBuild]]"
+
+// maxCauseDepth limits how many upstream builds are followed
+const maxCauseDepth = 50
+
+// GraphBuild will write the cause chain of a build, following the
+// upstream builds that triggered it
+//
+// Args:
+//	out - where the graph is written
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//	format - dot or mermaid
+//
+// Returns
+//	nil or error
+func (j *Jenkins) GraphBuild(out io.Writer, jobName string, build string, format string) error {
+	g := newDependencyGraph()
+
+	var trace func(jobName string, build string, depth int) (int, error)
+	trace = func(jobName string, build string, depth int) (int, error) {
+		b := buildCauses{}
+		if err := j.getJSON(buildBase(jobName, build), &b, map[string]string{"tree": causeTree}); err != nil {
+			return 0, err
+		}
+		key := fmt.Sprintf("%s#%d", jobName, b.Number)
+		if i, ok := g.index[key]; ok {
+			return i, nil
+		}
+		result := b.Result
+		if b.Building {
+			result = "IN_PROGRESS"
+		}
+		node := g.node(key, fmt.Sprintf("%s #%d (%s)", jobName, b.Number, result), resultStatus(b.Result, b.Building))
+		if depth >= maxCauseDepth {
+			return node, nil
+		}
+
+		for _, action := range b.Actions {
+			for _, cause := range action.Causes {
+				if cause.UpstreamProject == "" {
+					g.edge(g.node("cause:"+cause.ShortDescription, cause.ShortDescription, "cause"), node)
+					continue
+				}
+				upstream, err := trace(cause.UpstreamProject, strconv.FormatInt(cause.UpstreamBuild, 10), depth+1)
+				if err != nil {
+					// the upstream build may have been discarded
+					upstream = g.node("cause:"+cause.ShortDescription, cause.ShortDescription, "cause")
+				}
+				g.edge(upstream, node)
+			}
+		}
+		return node, nil
+	}
+
+	if _, err := trace(strings.Trim(jobName, "/"), build, 0); err != nil {
+		return err
+	}
+	return g.write(out, format)
+}
@@ -2,12 +2,14 @@ package jenkins
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/bndr/gojenkins"
 	"github.com/spf13/viper"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 )
@@ -19,13 +21,14 @@ type Jenkins struct {
 	JenkinsUser string
 	Token       string
 	Context     context.Context
+	transport   *countingTransport
 }
 
 // Config is focused in the configuration json file
 type Config struct {
-	Server         string `mapstructure: Server`
-	JenkinsUser    string `mapstructure: JenkinsUser`
-	Token          string `mapstructure: Token`
+	Server         string `mapstructure:"Server"`
+	JenkinsUser    string `mapstructure:"JenkinsUser"`
+	Token          string `mapstructure:"Token"`
 	ConfigPath     string
 	ConfigFileName string
 	ConfigFullPath string
@@ -106,17 +109,13 @@ func (j *Jenkins) PluginsShow() {
 // DeleteJob will delete a job
 //
 // Args:
-//	jobName - job name
+//	jobName - job full name, i.e: folder/job
 //
 // Returns:
 //	error or nil
 func (j *Jenkins) DeleteJob(jobName string) error {
-	job, err := j.Instance.GetJob(j.Context, jobName)
-	if err != nil {
-		return err
-	}
-
-	_, err = job.Delete(j.Context)
+	job := &gojenkins.Job{Jenkins: j.Instance, Raw: new(gojenkins.JobResponse), Base: jobBase(jobName)}
+	_, err := job.Delete(j.Context)
 
 	return err
 }
@@ -129,7 +128,7 @@ func (j *Jenkins) DeleteJob(jobName string) error {
 // Returns:
 //	error or nil
 func (j *Jenkins) JobGetConfig(jobName string) error {
-	job, err := j.Instance.GetJob(j.Context, jobName)
+	job, err := j.getJob(jobName)
 	if err != nil {
 		return err
 	}
@@ -146,9 +145,25 @@ func (j *Jenkins) JobGetConfig(jobName string) error {
 // Returns
 //
 func (j *Jenkins) ShowBuildQueue() error {
-	queue, _ := j.Instance.GetQueue(j.Context)
-	totalTasks := 0
-	for i, item := range queue.Raw.Items {
+	queue := struct {
+		Items []struct {
+			ID      int64  `json:"id"`
+			Pending bool   `json:"pending"`
+			Stuck   bool   `json:"stuck"`
+			Why     string `json:"why"`
+			Task    struct {
+				Name  string `json:"name"`
+				Color string `json:"color"`
+				URL   string `json:"url"`
+			} `json:"task"`
+		} `json:"items"`
+	}{}
+	err := j.getJSON("/queue", &queue, map[string]string{"tree": "items[id,pending,stuck,why,task[name,color,url]]"})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range queue.Items {
 		fmt.Printf("Name: %s\n", item.Task.Name)
 		fmt.Printf("ID: %d\n", item.ID)
 		j.ShowStatus(item.Task.Color)
@@ -158,9 +173,8 @@ func (j *Jenkins) ShowBuildQueue() error {
 		fmt.Printf("Why: %s\n", item.Why)
 		fmt.Printf("URL: %s\n", item.Task.URL)
 		fmt.Printf("\n")
-		totalTasks = i + 1
 	}
-	fmt.Printf("Number of tasks in the build queue: %d\n", totalTasks)
+	fmt.Printf("Number of tasks in the build queue: %d\n", len(queue.Items))
 
 	return nil
 }
@@ -198,7 +212,7 @@ func (j *Jenkins) ShowStatus(object string) {
 //	error or nil
 func (j *Jenkins) GetLastCompletedBuild(jobName string) error {
 	fmt.Printf("⏳ Collecting job information...\n")
-	job, err := j.Instance.GetJob(j.Context, jobName)
+	job, err := j.getJob(jobName)
 	if err != nil {
 		return errors.New("❌ unable to find the specific job")
 	}
@@ -220,14 +234,23 @@ func (j *Jenkins) GetLastCompletedBuild(jobName string) error {
 // CreateView will create a view
 //
 // Args:
+//	folder - folder full name, empty for the root
 //	viewname - view name
 //	viewType - view type
 //
 // Returns
 //	error or nil
-func (j *Jenkins) CreateView(viewName string, viewType string) error {
+func (j *Jenkins) CreateView(folder string, viewName string, viewType string) error {
 	fmt.Printf("%s\n", viewType)
-	_, err := j.Instance.CreateView(j.Context, viewName, viewType)
+	form, err := json.Marshal(map[string]string{"name": viewName, "mode": viewType})
+	if err != nil {
+		return err
+	}
+	err = j.postForm(jobBase(folder)+"/createView", url.Values{
+		"name": {viewName},
+		"mode": {viewType},
+		"json": {string(form)},
+	})
 	if err != nil {
 		return err
 	}
@@ -246,7 +269,7 @@ func (j *Jenkins) CreateView(viewName string, viewType string) error {
 // Returns:
 //	error or nil
 func (j *Jenkins) DownloadArtifacts(jobName string, buildID int64, pathToSave string) error {
-	job, err := j.Instance.GetJob(j.Context, jobName)
+	job, err := j.getJob(jobName)
 	if err != nil {
 		return errors.New("❌ unable to find the job")
 	}
@@ -280,7 +303,7 @@ func (j *Jenkins) DownloadArtifacts(jobName string, buildID int64, pathToSave st
 //	error or nil
 func (j *Jenkins) GetLastUnstableBuild(jobName string) error {
 	fmt.Printf("⏳ Collecting job information...\n")
-	job, err := j.Instance.GetJob(j.Context, jobName)
+	job, err := j.getJob(jobName)
 	if err != nil {
 		return errors.New("❌ unable to find the specific job")
 	}
@@ -308,7 +331,7 @@ func (j *Jenkins) GetLastUnstableBuild(jobName string) error {
 //	error or nil
 func (j *Jenkins) GetLastStableBuild(jobName string) error {
 	fmt.Printf("⏳ Collecting job information...\n")
-	job, err := j.Instance.GetJob(j.Context, jobName)
+	job, err := j.getJob(jobName)
 	if err != nil {
 		return errors.New("❌ unable to find the specific job")
 	}
@@ -336,7 +359,7 @@ func (j *Jenkins) GetLastStableBuild(jobName string) error {
 //	error or nil
 func (j *Jenkins) GetLastBuild(jobName string) error {
 	fmt.Printf("⏳ Collecting job information...\n")
-	job, err := j.Instance.GetJob(j.Context, jobName)
+	job, err := j.getJob(jobName)
 	if err != nil {
 		return errors.New("❌ unable to find the specific job")
 	}
@@ -364,7 +387,7 @@ func (j *Jenkins) GetLastBuild(jobName string) error {
 //	error or nil
 func (j *Jenkins) GetLastFailedBuild(jobName string) error {
 	fmt.Printf("⏳ Collecting job information...\n")
-	jobObj, err := j.Instance.GetJob(j.Context, jobName)
+	jobObj, err := j.getJob(jobName)
 	if err != nil {
 		return errors.New("❌ unable to find the specific job")
 	}
@@ -382,19 +405,6 @@ func (j *Jenkins) GetLastFailedBuild(jobName string) error {
 	return nil
 }
 
-// AddJobToView will add a specific job to a view
-func (j *Jenkins) AddJobToView(viewName string, jobName string) error {
-
-	view, _ := j.Instance.GetView(j.Context, viewName)
-	_, err := view.AddJob(j.Context, jobName)
-	if err != nil {
-		return err
-	}
-
-	return nil
-
-}
-
 // GetLastSuccessfulBuild will get last failed build
 //
 // Args:
@@ -404,7 +414,7 @@ func (j *Jenkins) AddJobToView(viewName string, jobName string) error {
 //	error or nil
 func (j *Jenkins) GetLastSuccessfulBuild(jobName string) error {
 	fmt.Printf("⏳ Collecting job information...\n")
-	jobObj, err := j.Instance.GetJob(j.Context, jobName)
+	jobObj, err := j.getJob(jobName)
 	if err != nil {
 		return errors.New("❌ unable to find the specific job")
 	}
@@ -429,35 +439,21 @@ func (j *Jenkins) GetLastSuccessfulBuild(jobName string) error {
 // Returns:
 //	error or nil
 func (j *Jenkins) ShowAllJobs() error {
-	jobs, err := j.Instance.GetAllJobs(j.Context)
-	if err != nil {
-		return err
-	}
-	for _, job := range jobs {
-		fmt.Printf("✅ %s\n", job.Raw.Name)
-		j.ShowStatus(job.Raw.Color)
-		fmt.Printf("%s\n", job.Raw.Description)
-		fmt.Printf("%s\n", job.Raw.URL)
-		fmt.Printf("\n")
-	}
-	return nil
-}
-
-// ShowViews will show all views
-//
-// Args:
-//
-// Returns:
-// 	error or nil
-func (j *Jenkins) ShowViews() error {
-	views, err := j.Instance.GetView(j.Context, "All")
+	response := struct {
+		Jobs []*JobInfo `json:"jobs"`
+	}{}
+	err := j.getJSON("/", &response, map[string]string{"tree": "jobs[_class,name,url,color,description]"})
 	if err != nil {
 		return err
 	}
-
-	for _, view := range views.Raw.Jobs {
-		fmt.Printf("✅ %s\n", view.Name)
-		fmt.Printf("%s\n", view.Url)
+	for _, job := range response.Jobs {
+		fmt.Printf("✅ %s\n", job.Name)
+		if kind := multibranchKind(job.Class); kind != "" {
+			fmt.Printf("Type: %s, use get branches %s\n", kind, job.Name)
+		}
+		j.ShowStatus(job.Color)
+		fmt.Printf("%s\n", job.Description)
+		fmt.Printf("%s\n", job.URL)
 		fmt.Printf("\n")
 	}
 	return nil
@@ -475,19 +471,13 @@ func getFileAsString(path string) (string, error) {
 // CreateJob will create a job based on XML specification
 //
 // Args:
-//	xmlFile	- Job described in XML format
-//	jobName - Job Name
+//	config - Job described in XML format
+//	jobName - Job full name, i.e: folder/job
 //
 // Returns:
 //	error or nil
-func (j *Jenkins) CreateJob(xmlFile string, jobName string) error {
-	jobData, err := getFileAsString(xmlFile)
-	if err != nil {
-		return err
-	}
-
-	_, err = j.Instance.CreateJob(j.Context, jobData, jobName)
-	return err
+func (j *Jenkins) CreateJob(config string, jobName string) error {
+	return j.createJobInFolder(jobName, config)
 }
 
 // ShowNodes show all plugins installed and enabled
@@ -500,31 +490,29 @@ func (j *Jenkins) CreateJob(xmlFile string, jobName string) error {
 func (j *Jenkins) ShowNodes(showStatus string) ([]string, error) {
 	var hosts []string
 
-	nodes, err := j.Instance.GetAllNodes(j.Context)
+	nodes, err := j.GetNodeStatus()
 	if err != nil {
 		return hosts, err
 	}
-	for _, node := range nodes {
-		// Fetch Node Data
-		node.Poll(j.Context)
 
+	for _, node := range nodes {
 		switch showStatus {
 
 		case "offline":
-			if node.Raw.Offline || node.Raw.TemporarilyOffline {
-				fmt.Printf("❌ %s - offline\n", node.GetName())
-				fmt.Printf("Reason: %s\n\n", node.Raw.OfflineCauseReason)
+			if node.Offline || node.TemporarilyOffline {
+				fmt.Printf("❌ %s - offline\n", node.DisplayName)
+				fmt.Printf("Reason: %s\n\n", node.OfflineCauseReason)
 			}
-			hosts = append(hosts, node.GetName())
+			hosts = append(hosts, node.DisplayName)
 
 		case "online":
-			if !node.Raw.Offline {
-				fmt.Printf("✅ %s - online\n", node.GetName())
+			if !node.Offline {
+				fmt.Printf("✅ %s - online\n", node.DisplayName)
 			}
-			if node.Raw.Idle {
-				fmt.Printf("😴 %s - idle\n", node.GetName())
+			if node.Idle {
+				fmt.Printf("😴 %s - idle\n", node.DisplayName)
 			}
-			hosts = append(hosts, node.GetName())
+			hosts = append(hosts, node.DisplayName)
 		}
 	}
 	return hosts, nil
@@ -541,9 +529,13 @@ func (j *Jenkins) Init(config Config) error {
 	j.Server = config.Server
 	j.Token = config.Token
 	j.Context = context.Background()
+	j.transport = &countingTransport{
+		base:    http.DefaultTransport,
+		context: func() context.Context { return j.Context },
+	}
 
 	j.Instance = gojenkins.CreateJenkins(
-		nil,
+		&http.Client{Transport: j.transport},
 		j.Server,
 		j.JenkinsUser,
 		j.Token)
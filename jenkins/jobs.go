@@ -0,0 +1,423 @@
+package jenkins
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/bndr/gojenkins"
+)
+
+// jobTreeDepth is how many levels of folders are collected by ListJobs
+const jobTreeDepth = 6
+
+// FolderClass is the class of the folders of the cloudbees-folder plugin
+const FolderClass = "com.cloudbees.hudson.plugins.folder.Folder"
+
+// jobFields are the fields always collected by ListJobs, _class is needed
+// by IsFolder
+const jobFields = "_class,name,fullName,url,color"
+
+// JobInfo is a job collected with a tree= query
+type JobInfo struct {
+	Class       string `json:"_class"`
+	Name        string `json:"name"`
+	FullName    string `json:"fullName"`
+	URL         string `json:"url"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+	LastBuild   *struct {
+		Number    int64  `json:"number"`
+		Result    string `json:"result"`
+		Timestamp int64  `json:"timestamp"`
+	} `json:"lastBuild"`
+	UpstreamProjects   []JobRef   `json:"upstreamProjects"`
+	DownstreamProjects []JobRef   `json:"downstreamProjects"`
+	Jobs               []*JobInfo `json:"jobs"`
+}
+
+// JobRef is a reference to another job, i.e: an upstream project
+type JobRef struct {
+	FullName string `json:"fullName"`
+	Color    string `json:"color"`
+}
+
+// LastBuildTime returns when the last build started, zero if never built
+func (job *JobInfo) LastBuildTime() time.Time {
+	if job.LastBuild == nil {
+		return time.Time{}
+	}
+	return time.Unix(0, job.LastBuild.Timestamp*int64(time.Millisecond))
+}
+
+// IsFolder returns true for folders, multibranch projects and organization
+// folders, they are the items that contain other jobs. The class is used
+// since jobs is not collected for folders past jobTreeDepth
+func (job *JobInfo) IsFolder() bool {
+	switch job.Class {
+	case FolderClass, MultibranchClass, OrganizationFolderClass:
+		return true
+	}
+	return false
+}
+
+// jobTree returns the tree= query for jobs and folders up to depth
+func jobTree(depth int, fields string) string {
+	tree := fields
+	if depth > 1 {
+		tree += ",jobs[" + jobTree(depth-1, fields) + "]"
+	}
+	return tree
+}
+
+// getJobTree will collect the jobs of a folder and its subfolders, the
+// folders deeper than jobTreeDepth are collected with another request
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	fields - fields of each job, must include _class
+//
+// Returns
+//	jobs and folders of the folder, nil or error
+func (j *Jenkins) getJobTree(folder string, fields string) ([]*JobInfo, error) {
+	endpoint := jobBase(folder)
+	if endpoint == "" {
+		endpoint = "/"
+	}
+	response := struct {
+		Jobs []*JobInfo `json:"jobs"`
+	}{}
+	err := j.getJSON(endpoint, &response, map[string]string{"tree": "jobs[" + jobTree(jobTreeDepth, fields) + "]"})
+	if err != nil {
+		return nil, err
+	}
+
+	var expand func(items []*JobInfo, depth int) error
+	expand = func(items []*JobInfo, depth int) error {
+		for _, item := range items {
+			if !item.IsFolder() {
+				continue
+			}
+			if depth == jobTreeDepth && item.Jobs == nil {
+				if item.Jobs, err = j.getJobTree(item.FullName, fields); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := expand(item.Jobs, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err = expand(response.Jobs, 1); err != nil {
+		return nil, err
+	}
+	return response.Jobs, nil
+}
+
+// ListJobs will collect all jobs, walking into folders, with a single
+// request, and one more per folder deeper than jobTreeDepth. Folders are
+// not part of the result
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	fields - extra fields to collect for each job, i.e: description
+//
+// Returns
+//	list of jobs, nil or error
+func (j *Jenkins) ListJobs(folder string, fields ...string) ([]*JobInfo, error) {
+	tree := jobFields
+	if len(fields) > 0 {
+		tree += "," + strings.Join(fields, ",")
+	}
+	items, err := j.getJobTree(folder, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*JobInfo
+	var walk func(items []*JobInfo)
+	walk = func(items []*JobInfo) {
+		for _, item := range items {
+			if item.IsFolder() {
+				walk(item.Jobs)
+				continue
+			}
+			jobs = append(jobs, item)
+		}
+	}
+	walk(items)
+	return jobs, nil
+}
+
+// relativeName returns the job full name relative to a folder
+func relativeName(folder string, fullName string) string {
+	folder = strings.Trim(folder, "/")
+	if folder == "" {
+		return fullName
+	}
+	return strings.TrimPrefix(fullName, folder+"/")
+}
+
+// parentName returns the folder full name of a job, empty for the root
+func parentName(fullName string) string {
+	fullName = strings.Trim(fullName, "/")
+	if i := strings.LastIndex(fullName, "/"); i >= 0 {
+		return fullName[:i]
+	}
+	return ""
+}
+
+// getJob will get a job by full name, the names are escaped so branch
+// jobs like feature%2Flogin work
+//
+// Args:
+//	fullName - job full name, i.e: folder/job
+//
+// Returns
+//	job, nil or error
+func (j *Jenkins) getJob(fullName string) (*gojenkins.Job, error) {
+	job := &gojenkins.Job{Jenkins: j.Instance, Raw: new(gojenkins.JobResponse), Base: jobBase(fullName)}
+	status, err := job.Poll(j.Context)
+	if err != nil {
+		return nil, err
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("%s returned status code: %d", job.Base, status)
+	}
+	return job, nil
+}
+
+// getJobConfig will get the config.xml of a job
+//
+// Args:
+//	fullName - job full name
+//
+// Returns
+//	config.xml, nil or error
+func (j *Jenkins) getJobConfig(fullName string) (string, error) {
+	var config string
+	endpoint := jobBase(fullName) + "/config.xml"
+	resp, err := j.Instance.Requester.GetXML(j.Context, endpoint, &config, nil)
+	if err != nil {
+		return "", err
+	}
+	if err = checkStatus(resp, endpoint); err != nil {
+		return "", err
+	}
+	return config, nil
+}
+
+// createJobInFolder will create a job from a config.xml
+//
+// Args:
+//	fullName - full name of the new job
+//	config - job described in XML format
+//
+// Returns
+//	nil or error
+func (j *Jenkins) createJobInFolder(fullName string, config string) error {
+	endpoint := jobBase(parentName(fullName)) + "/createItem"
+	resp, err := j.Instance.Requester.PostXML(j.Context, endpoint, config, nil, map[string]string{"name": path.Base(fullName)})
+	if err != nil {
+		return err
+	}
+	return checkStatus(resp, endpoint)
+}
+
+// postForm will post form values to an endpoint
+func (j *Jenkins) postForm(endpoint string, values url.Values) error {
+	resp, err := j.Instance.Requester.Post(j.Context, endpoint, strings.NewReader(values.Encode()), nil, nil)
+	if err != nil {
+		return err
+	}
+	return checkStatus(resp, endpoint)
+}
+
+// CopyJob will copy a job using createItem?mode=copy, the new job is
+// added to the views of the source job
+//
+// Args:
+//	src - full name of the job to copy
+//	dst - full name of the new job
+//
+// Returns
+//	nil or error
+func (j *Jenkins) CopyJob(src string, dst string) error {
+	views, err := j.viewsContaining(src)
+	if err != nil {
+		return err
+	}
+
+	endpoint := jobBase(parentName(dst)) + "/createItem"
+	query := map[string]string{"name": path.Base(dst), "mode": "copy", "from": "/" + strings.Trim(src, "/")}
+	resp, err := j.Instance.Requester.Post(j.Context, endpoint, nil, nil, query)
+	if err != nil {
+		return err
+	}
+	if err = checkStatus(resp, endpoint); err != nil {
+		return err
+	}
+
+	// copied jobs cannot be built until the config is saved once
+	config, err := j.getJobConfig(dst)
+	if err != nil {
+		return err
+	}
+	resp, err = j.Instance.Requester.PostXML(j.Context, jobBase(dst)+"/config.xml", config, nil, nil)
+	if err != nil {
+		return err
+	}
+	if err = checkStatus(resp, jobBase(dst)+"/config.xml"); err != nil {
+		return err
+	}
+
+	j.restoreViewMembership(views, dst)
+	return nil
+}
+
+// RenameJob will rename a job with doRename, the views are updated by
+// jenkins but the membership is verified
+//
+// Args:
+//	oldName - job full name
+//	newName - new job name, in the same folder
+//
+// Returns
+//	nil or error
+func (j *Jenkins) RenameJob(oldName string, newName string) error {
+	if strings.Contains(newName, "/") {
+		return errors.New("the new name cannot contain /, use job move to change the folder")
+	}
+	views, err := j.viewsContaining(oldName)
+	if err != nil {
+		return err
+	}
+
+	err = j.postForm(jobBase(oldName)+"/doRename", url.Values{"newName": {newName}})
+	if err != nil {
+		return err
+	}
+
+	newFullName := path.Join(parentName(oldName), newName)
+	j.ensureViewMembership(views, newFullName)
+	return nil
+}
+
+// MoveJob will move a job to another folder using the cloudbees-folder
+// move action. When the action is not available (404) and copyDelete is
+// set, the job is exported, created in the folder and deleted, the build
+// history is lost
+//
+// Args:
+//	fullName - job full name
+//	folder - destination folder full name, empty for the root
+//	copyDelete - use export, create and delete when move is not available
+//
+// Returns
+//	nil or error
+func (j *Jenkins) MoveJob(fullName string, folder string, copyDelete bool) error {
+	folder = strings.Trim(folder, "/")
+	views, err := j.viewsContaining(fullName)
+	if err != nil {
+		return err
+	}
+	newFullName := path.Join(folder, path.Base(fullName))
+
+	endpoint := jobBase(fullName) + "/move/move"
+	values := url.Values{"destination": {"/" + folder}}
+	resp, err := j.Instance.Requester.Post(j.Context, endpoint, strings.NewReader(values.Encode()), nil, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 404 {
+		if err = checkStatus(resp, endpoint); err != nil {
+			return err
+		}
+		j.ensureViewMembership(views, newFullName)
+		return nil
+	}
+	if !copyDelete {
+		return errors.New("move action not available (cloudbees-folder plugin), use --copy-delete to export, create and delete the job, the build history is lost")
+	}
+
+	fmt.Printf("⚠️  move action not available, using export, create and delete\n")
+	config, err := j.getJobConfig(fullName)
+	if err != nil {
+		return err
+	}
+	if err = j.createJobInFolder(newFullName, config); err != nil {
+		return err
+	}
+	if err = j.DeleteJob(fullName); err != nil {
+		return fmt.Errorf("job created in %s but the old job was not deleted: %s", newFullName, err)
+	}
+	j.restoreViewMembership(views, newFullName)
+	return nil
+}
+
+// ensureViewMembership will add the job to the views it is missing from
+func (j *Jenkins) ensureViewMembership(views []viewRef, fullName string) {
+	if len(views) == 0 {
+		return
+	}
+	current, err := j.viewsContaining(fullName)
+	if err != nil {
+		fmt.Printf("⚠️  unable to verify the views of %s: %s\n", fullName, err)
+		return
+	}
+	found := map[viewRef]bool{}
+	for _, ref := range current {
+		found[ref] = true
+	}
+	var missing []viewRef
+	for _, ref := range views {
+		if !found[ref] {
+			missing = append(missing, ref)
+		}
+	}
+	j.restoreViewMembership(missing, fullName)
+}
+
+// jobExists returns true when the job exists in the server
+func (j *Jenkins) jobExists(fullName string) (bool, error) {
+	endpoint := jobBase(fullName)
+	resp, err := j.Instance.Requester.GetJSON(j.Context, endpoint, &struct{}{}, map[string]string{"tree": "name"})
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == 404 {
+		return false, nil
+	}
+	return true, checkStatus(resp, endpoint)
+}
+
+// ApplyJob will update the config.xml of a job or create it when it
+// does not exist
+//
+// Args:
+//	config - Job described in XML format
+//	jobName - Job full name, i.e: folder/job
+//
+// Returns:
+//	true when the job was created, nil or error
+func (j *Jenkins) ApplyJob(config string, jobName string) (bool, error) {
+	exists, err := j.jobExists(jobName)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return true, j.createJobInFolder(jobName, config)
+	}
+
+	endpoint := jobBase(jobName) + "/config.xml"
+	resp, err := j.Instance.Requester.PostXML(j.Context, endpoint, config, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	return false, checkStatus(resp, endpoint)
+}
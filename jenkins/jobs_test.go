@@ -0,0 +1,89 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIsFolder(t *testing.T) {
+	tests := []struct {
+		job  JobInfo
+		want bool
+	}{
+		// folders past jobTreeDepth have no jobs field
+		{job: JobInfo{Class: FolderClass}, want: true},
+		{job: JobInfo{Class: MultibranchClass, Jobs: []*JobInfo{}}, want: true},
+		{job: JobInfo{Class: OrganizationFolderClass}, want: true},
+		{job: JobInfo{Class: "hudson.model.FreeStyleProject"}, want: false},
+		{job: JobInfo{Class: "org.jenkinsci.plugins.workflow.job.WorkflowJob", Jobs: []*JobInfo{}}, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.job.IsFolder(); got != tt.want {
+			t.Errorf("%s: IsFolder() = %t, want %t", tt.job.Class, got, tt.want)
+		}
+	}
+}
+
+func TestListJobsDeepFolders(t *testing.T) {
+	// f1/.../f6 is at the depth limit, its jobs are not in the first response
+	folder := func(name string, jobs ...interface{}) map[string]interface{} {
+		item := map[string]interface{}{"_class": FolderClass, "fullName": name}
+		if jobs != nil {
+			item["jobs"] = jobs
+		}
+		return item
+	}
+	job := func(name string) map[string]interface{} {
+		return map[string]interface{}{"_class": "hudson.model.FreeStyleProject", "fullName": name}
+	}
+	deep := folder("f1/f2/f3/f4/f5/f6")
+	for _, name := range []string{"f1/f2/f3/f4/f5", "f1/f2/f3/f4", "f1/f2/f3", "f1/f2", "f1"} {
+		deep = folder(name, deep)
+	}
+	routes := map[string]interface{}{
+		"/api/json": map[string]interface{}{"jobs": []interface{}{job("top"), deep}},
+		"/job/f1/job/f2/job/f3/job/f4/job/f5/job/f6/api/json": map[string]interface{}{"jobs": []interface{}{
+			folder("f1/f2/f3/f4/f5/f6/f7", job("f1/f2/f3/f4/f5/f6/f7/deep")),
+		}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := routes[strings.TrimSuffix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	j := &Jenkins{}
+	if err := j.Init(Config{Server: server.URL, JenkinsUser: "user", Token: "token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := j.ListJobs("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, job := range jobs {
+		names = append(names, job.FullName)
+	}
+	want := []string{"top", "f1/f2/f3/f4/f5/f6/f7/deep"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("ListJobs() = %v, want %v", names, want)
+	}
+
+	names, err = j.JobNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 9 || names[len(names)-1] != "top" {
+		t.Errorf("JobNames() = %v, want 7 folders and 2 jobs", names)
+	}
+}
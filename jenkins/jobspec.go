@@ -0,0 +1,679 @@
+package jenkins
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Job types supported by the YAML job spec
+const (
+	JobTypeFreestyle = "freestyle"
+	JobTypePipeline  = "pipeline"
+)
+
+// JobSpec is a job described in YAML, see xml-example/job.yaml
+type JobSpec struct {
+	Name        string          `yaml:"name,omitempty"`
+	Type        string          `yaml:"type"`
+	Description string          `yaml:"description,omitempty"`
+	Disabled    bool            `yaml:"disabled,omitempty"`
+	Parameters  []ParameterSpec `yaml:"parameters,omitempty"`
+	SCM         *SCMSpec        `yaml:"scm,omitempty"`
+	Triggers    *TriggerSpec    `yaml:"triggers,omitempty"`
+	Shell       []string        `yaml:"shell,omitempty"`
+	Pipeline    *PipelineSpec   `yaml:"pipeline,omitempty"`
+	LogRotator  *LogRotatorSpec `yaml:"logRotator,omitempty"`
+}
+
+// ParameterSpec is a build parameter, type is one of string, text,
+// boolean, choice or password
+type ParameterSpec struct {
+	Name        string   `yaml:"name"`
+	Type        string   `yaml:"type,omitempty"`
+	Default     string   `yaml:"default,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Choices     []string `yaml:"choices,omitempty"`
+}
+
+// SCMSpec is a git repository
+type SCMSpec struct {
+	URL         string `yaml:"url"`
+	Branch      string `yaml:"branch,omitempty"`
+	Credentials string `yaml:"credentials,omitempty"`
+}
+
+// TriggerSpec are the cron expressions used to start a build
+type TriggerSpec struct {
+	Cron    string `yaml:"cron,omitempty"`
+	PollSCM string `yaml:"pollSCM,omitempty"`
+}
+
+// PipelineSpec is the pipeline definition, an inline script or the
+// path of the Jenkinsfile in the scm
+type PipelineSpec struct {
+	Script     string `yaml:"script,omitempty"`
+	Sandbox    *bool  `yaml:"sandbox,omitempty"`
+	ScriptPath string `yaml:"scriptPath,omitempty"`
+}
+
+// LogRotatorSpec is the build discarder, zero means keep all
+type LogRotatorSpec struct {
+	DaysToKeep         int `yaml:"daysToKeep,omitempty"`
+	NumToKeep          int `yaml:"numToKeep,omitempty"`
+	ArtifactDaysToKeep int `yaml:"artifactDaysToKeep,omitempty"`
+	ArtifactNumToKeep  int `yaml:"artifactNumToKeep,omitempty"`
+}
+
+// parameterClasses maps the spec parameter types to Jenkins classes
+var parameterClasses = map[string]string{
+	"string":   "hudson.model.StringParameterDefinition",
+	"text":     "hudson.model.TextParameterDefinition",
+	"boolean":  "hudson.model.BooleanParameterDefinition",
+	"choice":   "hudson.model.ChoiceParameterDefinition",
+	"password": "hudson.model.PasswordParameterDefinition",
+}
+
+// IsJobSpec returns true for YAML job specs, i.e: job.yaml
+func IsJobSpec(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// readInput reads a file or stdin when path is -
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// ReadJobSpec will read and validate a YAML job spec
+//
+// Args:
+//	path - path to the YAML file or - for stdin
+//
+// Returns
+//	job spec, nil or error
+func ReadJobSpec(path string) (*JobSpec, error) {
+	data, err := readInput(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &JobSpec{}
+	if err = yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	if err = spec.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return spec, nil
+}
+
+// Validate will check the spec and set the default values
+func (s *JobSpec) Validate() error {
+	if s.Type == "" {
+		s.Type = JobTypeFreestyle
+	}
+	switch s.Type {
+	case JobTypeFreestyle:
+		if s.Pipeline != nil {
+			return fmt.Errorf("pipeline is only valid for type %s", JobTypePipeline)
+		}
+	case JobTypePipeline:
+		if len(s.Shell) > 0 {
+			return fmt.Errorf("shell is only valid for type %s", JobTypeFreestyle)
+		}
+		if s.Pipeline == nil || (s.Pipeline.Script == "") == (s.Pipeline.ScriptPath == "") {
+			return fmt.Errorf("pipeline requires either script or scriptPath")
+		}
+		if s.Pipeline.ScriptPath != "" && s.SCM == nil {
+			return fmt.Errorf("pipeline scriptPath requires scm")
+		}
+		if s.Pipeline.ScriptPath == "" && s.SCM != nil {
+			return fmt.Errorf("scm is only used with pipeline scriptPath")
+		}
+	default:
+		return fmt.Errorf("unknown job type: %s (use %s or %s)", s.Type, JobTypeFreestyle, JobTypePipeline)
+	}
+
+	if s.SCM != nil && s.SCM.URL == "" {
+		return fmt.Errorf("scm requires url")
+	}
+	for i := range s.Parameters {
+		p := &s.Parameters[i]
+		if p.Name == "" {
+			return fmt.Errorf("parameter %d requires name", i+1)
+		}
+		if p.Type == "" {
+			p.Type = "string"
+		}
+		if _, ok := parameterClasses[p.Type]; !ok {
+			return fmt.Errorf("parameter %s has unknown type: %s", p.Name, p.Type)
+		}
+		if p.Type == "choice" && len(p.Choices) == 0 {
+			return fmt.Errorf("parameter %s requires choices", p.Name)
+		}
+		if p.Type == "boolean" && p.Default != "" {
+			if _, err := strconv.ParseBool(p.Default); err != nil {
+				return fmt.Errorf("parameter %s default must be true or false", p.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// xmlNode is a generic XML element, used to build and read job configs
+// keeping the order of the elements
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Text    string     `xml:",chardata"`
+	Nodes   []*xmlNode `xml:",any"`
+}
+
+// element returns a new node with the given children
+func element(name string, children ...*xmlNode) *xmlNode {
+	return &xmlNode{XMLName: xml.Name{Local: name}, Nodes: children}
+}
+
+// textElement returns a new node with text content
+func textElement(name string, text string) *xmlNode {
+	return &xmlNode{XMLName: xml.Name{Local: name}, Text: text}
+}
+
+// withAttr adds an attribute to the node
+func (n *xmlNode) withAttr(name string, value string) *xmlNode {
+	n.Attrs = append(n.Attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	return n
+}
+
+// add appends children to the node
+func (n *xmlNode) add(children ...*xmlNode) *xmlNode {
+	n.Nodes = append(n.Nodes, children...)
+	return n
+}
+
+// attr returns the value of an attribute
+func (n *xmlNode) attr(name string) string {
+	if n == nil {
+		return ""
+	}
+	for _, a := range n.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// child returns the first child with the given name, nil when missing
+func (n *xmlNode) child(name string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.Nodes {
+		if c.XMLName.Local == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// value returns the text of the first child with the given name
+func (n *xmlNode) value(name string) string {
+	c := n.child(name)
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSpace(c.Text)
+}
+
+// isEmpty returns true for nodes without children and meaningful text
+func (n *xmlNode) isEmpty() bool {
+	text := strings.TrimSpace(n.Text)
+	return len(n.Nodes) == 0 && (text == "" || text == "false")
+}
+
+// keepValue converts the spec log rotator values, where zero means keep all
+func keepValue(value int) string {
+	if value <= 0 {
+		return "-1"
+	}
+	return strconv.Itoa(value)
+}
+
+// specValue converts the log rotator values back to the spec
+func specValue(value string) int {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < 0 {
+		return 0
+	}
+	return v
+}
+
+// gitSCMNode returns the scm element for a git repository
+func gitSCMNode(scm *SCMSpec) *xmlNode {
+	branch := scm.Branch
+	if branch == "" {
+		branch = "*/master"
+	} else if !strings.Contains(branch, "/") {
+		branch = "*/" + branch
+	}
+	remote := element("hudson.plugins.git.UserRemoteConfig", textElement("url", scm.URL))
+	if scm.Credentials != "" {
+		remote.add(textElement("credentialsId", scm.Credentials))
+	}
+	return element("scm",
+		textElement("configVersion", "2"),
+		element("userRemoteConfigs", remote),
+		element("branches", element("hudson.plugins.git.BranchSpec", textElement("name", branch))),
+		textElement("doGenerateSubmoduleConfigurations", "false"),
+		element("submoduleCfg").withAttr("class", "empty-list"),
+		element("extensions"),
+	).withAttr("class", "hudson.plugins.git.GitSCM").withAttr("plugin", "git")
+}
+
+// triggerNodes returns the trigger elements of the spec
+func triggerNodes(triggers *TriggerSpec) []*xmlNode {
+	var nodes []*xmlNode
+	if triggers == nil {
+		return nodes
+	}
+	if triggers.Cron != "" {
+		nodes = append(nodes, element("hudson.triggers.TimerTrigger", textElement("spec", triggers.Cron)))
+	}
+	if triggers.PollSCM != "" {
+		nodes = append(nodes, element("hudson.triggers.SCMTrigger",
+			textElement("spec", triggers.PollSCM),
+			textElement("ignorePostCommitHooks", "false")))
+	}
+	return nodes
+}
+
+// propertyNodes returns the job properties of the spec
+func propertyNodes(s *JobSpec) []*xmlNode {
+	var nodes []*xmlNode
+	if s.LogRotator != nil {
+		nodes = append(nodes, element("jenkins.model.BuildDiscarderProperty",
+			element("strategy",
+				textElement("daysToKeep", keepValue(s.LogRotator.DaysToKeep)),
+				textElement("numToKeep", keepValue(s.LogRotator.NumToKeep)),
+				textElement("artifactDaysToKeep", keepValue(s.LogRotator.ArtifactDaysToKeep)),
+				textElement("artifactNumToKeep", keepValue(s.LogRotator.ArtifactNumToKeep)),
+			).withAttr("class", "hudson.tasks.LogRotator")))
+	}
+
+	if len(s.Parameters) > 0 {
+		definitions := element("parameterDefinitions")
+		for _, p := range s.Parameters {
+			param := element(parameterClasses[p.Type],
+				textElement("name", p.Name),
+				textElement("description", p.Description))
+			switch p.Type {
+			case "choice":
+				choices := element("a").withAttr("class", "string-array")
+				for _, c := range p.Choices {
+					choices.add(textElement("string", c))
+				}
+				param.add(element("choices", choices).withAttr("class", "java.util.Arrays$ArrayList"))
+			case "boolean":
+				value := "false"
+				if b, _ := strconv.ParseBool(p.Default); b {
+					value = "true"
+				}
+				param.add(textElement("defaultValue", value))
+			case "string":
+				param.add(textElement("defaultValue", p.Default), textElement("trim", "false"))
+			default:
+				param.add(textElement("defaultValue", p.Default))
+			}
+			definitions.add(param)
+		}
+		nodes = append(nodes, element("hudson.model.ParametersDefinitionProperty", definitions))
+	}
+
+	if s.Type == JobTypePipeline {
+		if triggers := triggerNodes(s.Triggers); len(triggers) > 0 {
+			nodes = append(nodes, element("org.jenkinsci.plugins.workflow.job.properties.PipelineTriggersJobProperty",
+				element("triggers", triggers...)))
+		}
+	}
+	return nodes
+}
+
+// GenerateJobXML will convert a job spec into a Jenkins config.xml
+//
+// Args:
+//	s - job spec
+//
+// Returns
+//	config.xml, nil or error
+func GenerateJobXML(s *JobSpec) (string, error) {
+	if err := s.Validate(); err != nil {
+		return "", err
+	}
+
+	var root *xmlNode
+	if s.Type == JobTypePipeline {
+		sandbox := true
+		if s.Pipeline.Sandbox != nil {
+			sandbox = *s.Pipeline.Sandbox
+		}
+		var definition *xmlNode
+		if s.Pipeline.ScriptPath != "" {
+			definition = element("definition",
+				gitSCMNode(s.SCM),
+				textElement("scriptPath", s.Pipeline.ScriptPath),
+				textElement("lightweight", "true"),
+			).withAttr("class", "org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition").withAttr("plugin", "workflow-cps")
+		} else {
+			definition = element("definition",
+				textElement("script", s.Pipeline.Script),
+				textElement("sandbox", strconv.FormatBool(sandbox)),
+			).withAttr("class", "org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition").withAttr("plugin", "workflow-cps")
+		}
+		root = element("flow-definition",
+			element("actions"),
+			textElement("description", s.Description),
+			textElement("keepDependencies", "false"),
+			element("properties", propertyNodes(s)...),
+			definition,
+			element("triggers"),
+			textElement("disabled", strconv.FormatBool(s.Disabled)),
+		).withAttr("plugin", "workflow-job")
+	} else {
+		scm := element("scm").withAttr("class", "hudson.scm.NullSCM")
+		if s.SCM != nil {
+			scm = gitSCMNode(s.SCM)
+		}
+		builders := element("builders")
+		for _, command := range s.Shell {
+			builders.add(element("hudson.tasks.Shell", textElement("command", command)))
+		}
+		root = element("project",
+			element("actions"),
+			textElement("description", s.Description),
+			textElement("keepDependencies", "false"),
+			element("properties", propertyNodes(s)...),
+			scm,
+			textElement("canRoam", "true"),
+			textElement("disabled", strconv.FormatBool(s.Disabled)),
+			textElement("blockBuildWhenDownstreamBuilding", "false"),
+			textElement("blockBuildWhenUpstreamBuilding", "false"),
+			element("triggers", triggerNodes(s.Triggers)...),
+			textElement("concurrentBuild", "false"),
+			builders,
+			element("publishers"),
+			element("buildWrappers"),
+		)
+	}
+
+	data, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	// keep scripts readable, no attribute value contains new lines
+	document := strings.Replace(string(data), "&#xA;", "\n", -1)
+	return "<?xml version='1.0' encoding='UTF-8'?>\n" + document + "\n", nil
+}
+
+// readGitSCM converts a scm element into the spec, warnings are returned
+// for unsupported scm classes
+func readGitSCM(scm *xmlNode, where string) (*SCMSpec, []string) {
+	switch scm.attr("class") {
+	case "hudson.scm.NullSCM", "":
+		return nil, nil
+	case "hudson.plugins.git.GitSCM":
+	default:
+		return nil, []string{fmt.Sprintf("%s: unsupported scm %s", where, scm.attr("class"))}
+	}
+
+	var warnings []string
+	spec := &SCMSpec{}
+	remotes := scm.child("userRemoteConfigs")
+	if remotes != nil && len(remotes.Nodes) > 0 {
+		spec.URL = remotes.Nodes[0].value("url")
+		spec.Credentials = remotes.Nodes[0].value("credentialsId")
+		if len(remotes.Nodes) > 1 {
+			warnings = append(warnings, fmt.Sprintf("%s: only the first git remote is kept", where))
+		}
+	}
+	branches := scm.child("branches")
+	if branches != nil && len(branches.Nodes) > 0 {
+		spec.Branch = strings.TrimPrefix(branches.Nodes[0].value("name"), "*/")
+		if len(branches.Nodes) > 1 {
+			warnings = append(warnings, fmt.Sprintf("%s: only the first git branch is kept", where))
+		}
+	}
+	if ext := scm.child("extensions"); ext != nil && len(ext.Nodes) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%s: git extensions are not supported", where))
+	}
+	return spec, warnings
+}
+
+// readTriggers converts the trigger elements into the spec
+func readTriggers(triggers *xmlNode, spec *JobSpec) []string {
+	var warnings []string
+	for _, t := range triggers.Nodes {
+		switch t.XMLName.Local {
+		case "hudson.triggers.TimerTrigger":
+			if spec.Triggers == nil {
+				spec.Triggers = &TriggerSpec{}
+			}
+			spec.Triggers.Cron = t.value("spec")
+		case "hudson.triggers.SCMTrigger":
+			if spec.Triggers == nil {
+				spec.Triggers = &TriggerSpec{}
+			}
+			spec.Triggers.PollSCM = t.value("spec")
+		default:
+			warnings = append(warnings, fmt.Sprintf("unsupported trigger %s", t.XMLName.Local))
+		}
+	}
+	return warnings
+}
+
+// readProperties converts the job properties into the spec
+func readProperties(properties *xmlNode, spec *JobSpec) []string {
+	var warnings []string
+	for _, p := range properties.Nodes {
+		switch p.XMLName.Local {
+		case "jenkins.model.BuildDiscarderProperty":
+			strategy := p.child("strategy")
+			if strategy.attr("class") != "hudson.tasks.LogRotator" {
+				warnings = append(warnings, fmt.Sprintf("unsupported build discarder %s", strategy.attr("class")))
+				continue
+			}
+			spec.LogRotator = &LogRotatorSpec{
+				DaysToKeep:         specValue(strategy.value("daysToKeep")),
+				NumToKeep:          specValue(strategy.value("numToKeep")),
+				ArtifactDaysToKeep: specValue(strategy.value("artifactDaysToKeep")),
+				ArtifactNumToKeep:  specValue(strategy.value("artifactNumToKeep")),
+			}
+		case "hudson.model.ParametersDefinitionProperty":
+			definitions := p.child("parameterDefinitions")
+			if definitions == nil {
+				continue
+			}
+			for _, d := range definitions.Nodes {
+				paramType := ""
+				for t, class := range parameterClasses {
+					if class == d.XMLName.Local {
+						paramType = t
+					}
+				}
+				if paramType == "" {
+					warnings = append(warnings, fmt.Sprintf("parameter %s: unsupported type %s", d.value("name"), d.XMLName.Local))
+					continue
+				}
+				param := ParameterSpec{
+					Name:        d.value("name"),
+					Type:        paramType,
+					Description: d.value("description"),
+					Default:     d.value("defaultValue"),
+				}
+				if paramType == "boolean" && param.Default == "false" {
+					param.Default = ""
+				}
+				if choices := d.child("choices"); choices != nil {
+					values := choices
+					if a := choices.child("a"); a != nil {
+						values = a
+					}
+					for _, c := range values.Nodes {
+						param.Choices = append(param.Choices, strings.TrimSpace(c.Text))
+					}
+				}
+				spec.Parameters = append(spec.Parameters, param)
+			}
+		case "org.jenkinsci.plugins.workflow.job.properties.PipelineTriggersJobProperty":
+			if triggers := p.child("triggers"); triggers != nil {
+				warnings = append(warnings, readTriggers(triggers, spec)...)
+			}
+		default:
+			warnings = append(warnings, fmt.Sprintf("unsupported property %s", p.XMLName.Local))
+		}
+	}
+	return warnings
+}
+
+// stripXMLDeclaration removes the <?xml ...?> declaration, recent
+// Jenkins versions write version 1.1 which encoding/xml does not accept
+func stripXMLDeclaration(document string) string {
+	trimmed := strings.TrimSpace(document)
+	if strings.HasPrefix(trimmed, "<?xml") {
+		if end := strings.Index(trimmed, "?>"); end >= 0 {
+			return trimmed[end+2:]
+		}
+	}
+	return document
+}
+
+// ignoredElements are the config.xml elements that have no spec
+// equivalent with their default value, they are only reported when the
+// value is changed
+var ignoredElements = map[string]string{
+	"actions":                          "",
+	"keepDependencies":                 "false",
+	"canRoam":                          "true",
+	"blockBuildWhenDownstreamBuilding": "false",
+	"blockBuildWhenUpstreamBuilding":   "false",
+	"concurrentBuild":                  "false",
+	"publishers":                       "",
+	"buildWrappers":                    "",
+}
+
+// JobSpecFromXML will convert a job config.xml into a job spec, settings
+// without a spec equivalent are returned as warnings
+//
+// Args:
+//	config - job config.xml
+//
+// Returns
+//	job spec, warnings, nil or error
+func JobSpecFromXML(config string) (*JobSpec, []string, error) {
+	root := &xmlNode{}
+	if err := xml.Unmarshal([]byte(stripXMLDeclaration(config)), root); err != nil {
+		return nil, nil, err
+	}
+
+	spec := &JobSpec{}
+	switch root.XMLName.Local {
+	case "project":
+		spec.Type = JobTypeFreestyle
+	case "flow-definition":
+		spec.Type = JobTypePipeline
+	default:
+		return nil, nil, fmt.Errorf("unsupported job type: %s", root.XMLName.Local)
+	}
+
+	var warnings []string
+	for _, n := range root.Nodes {
+		name := n.XMLName.Local
+		switch name {
+		case "description":
+			spec.Description = strings.TrimSpace(n.Text)
+		case "disabled":
+			spec.Disabled = strings.TrimSpace(n.Text) == "true"
+		case "properties":
+			warnings = append(warnings, readProperties(n, spec)...)
+		case "triggers":
+			warnings = append(warnings, readTriggers(n, spec)...)
+		case "scm":
+			var w []string
+			spec.SCM, w = readGitSCM(n, "scm")
+			warnings = append(warnings, w...)
+		case "builders":
+			for _, b := range n.Nodes {
+				if b.XMLName.Local != "hudson.tasks.Shell" {
+					warnings = append(warnings, fmt.Sprintf("unsupported builder %s", b.XMLName.Local))
+					continue
+				}
+				spec.Shell = append(spec.Shell, b.value("command"))
+			}
+		case "definition":
+			spec.Pipeline = &PipelineSpec{}
+			switch n.attr("class") {
+			case "org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition":
+				spec.Pipeline.Script = n.value("script")
+				if n.value("sandbox") != "true" {
+					sandbox := false
+					spec.Pipeline.Sandbox = &sandbox
+				}
+			case "org.jenkinsci.plugins.workflow.cps.CpsScmFlowDefinition":
+				spec.Pipeline.ScriptPath = n.value("scriptPath")
+				var w []string
+				spec.SCM, w = readGitSCM(n.child("scm"), "pipeline scm")
+				warnings = append(warnings, w...)
+			default:
+				warnings = append(warnings, fmt.Sprintf("unsupported pipeline definition %s", n.attr("class")))
+			}
+		default:
+			def, ignored := ignoredElements[name]
+			if ignored && len(n.Nodes) == 0 && strings.TrimSpace(n.Text) == def {
+				continue
+			}
+			if ignored || !n.isEmpty() {
+				warnings = append(warnings, fmt.Sprintf("unsupported element %s", name))
+			}
+		}
+	}
+	return spec, warnings, nil
+}
+
+// ConvertJobFile will convert a YAML job spec into config.xml or a
+// config.xml into a YAML job spec, based on the file extension
+//
+// Args:
+//	path - path to the YAML or XML file, - reads XML from stdin
+//
+// Returns
+//	converted document, warnings, nil or error
+func ConvertJobFile(path string) (string, []string, error) {
+	if IsJobSpec(path) {
+		spec, err := ReadJobSpec(path)
+		if err != nil {
+			return "", nil, err
+		}
+		config, err := GenerateJobXML(spec)
+		return config, nil, err
+	}
+
+	data, err := readInput(path)
+	if err != nil {
+		return "", nil, err
+	}
+	spec, warnings, err := JobSpecFromXML(string(data))
+	if err != nil {
+		return "", nil, err
+	}
+	out, err := yaml.Marshal(spec)
+	return string(out), warnings, err
+}
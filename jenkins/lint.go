@@ -0,0 +1,414 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Lint severities, only errors make the lint fail
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// defaultPasswordPattern matches parameter names that hold secrets
+const defaultPasswordPattern = `(?i)pass|secret|token|api[_-]?key`
+
+// LintRule is a policy checked against a job config.xml
+type LintRule struct {
+	ID          string `json:"id" yaml:"id"`
+	Description string `json:"description" yaml:"description"`
+	Severity    string `json:"severity" yaml:"severity"`
+	pattern     *regexp.Regexp
+	check       func(rule *LintRule, root *xmlNode) []string
+}
+
+// LintRuleConfig overrides the defaults of a rule in the rules file
+type LintRuleConfig struct {
+	Enabled  *bool  `yaml:"enabled"`
+	Severity string `yaml:"severity"`
+	Pattern  string `yaml:"pattern"`
+}
+
+// LintFinding is a rule violation
+type LintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// LintResult are the violations found in a job, Error is set when the
+// config could not be read
+type LintResult struct {
+	Job      string        `json:"job"`
+	Error    string        `json:"error,omitempty"`
+	Findings []LintFinding `json:"findings"`
+}
+
+// DefaultLintRules returns the built-in rules
+func DefaultLintRules() []*LintRule {
+	return []*LintRule{
+		{
+			ID:          "missing-description",
+			Description: "job has no description",
+			Severity:    SeverityWarning,
+			check: func(rule *LintRule, root *xmlNode) []string {
+				if root.value("description") == "" {
+					return []string{"description is empty"}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "no-log-rotation",
+			Description: "job keeps all builds, no buildDiscarder or logRotator",
+			Severity:    SeverityError,
+			check: func(rule *LintRule, root *xmlNode) []string {
+				if root.child("properties").child("jenkins.model.BuildDiscarderProperty") == nil && root.child("logRotator") == nil {
+					return []string{"no buildDiscarder, all builds are kept"}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "concurrent-without-throttle",
+			Description: "concurrent builds are allowed without throttling",
+			Severity:    SeverityWarning,
+			check: func(rule *LintRule, root *xmlNode) []string {
+				properties := root.child("properties")
+				concurrent := root.value("concurrentBuild") == "true"
+				if root.XMLName.Local == "flow-definition" {
+					concurrent = properties.child("org.jenkinsci.plugins.workflow.job.properties.DisableConcurrentBuildsJobProperty") == nil
+				}
+				throttle := properties.child("hudson.plugins.throttleconcurrents.ThrottleJobProperty")
+				if concurrent && throttle.value("throttleEnabled") != "true" {
+					return []string{"concurrent builds enabled without throttling"}
+				}
+				return nil
+			},
+		},
+		{
+			ID:          "plain-text-password",
+			Description: "parameter default values with plain-text secrets",
+			Severity:    SeverityError,
+			check: func(rule *LintRule, root *xmlNode) []string {
+				var problems []string
+				definitions := root.child("properties").child("hudson.model.ParametersDefinitionProperty").child("parameterDefinitions")
+				if definitions == nil {
+					return nil
+				}
+				for _, d := range definitions.Nodes {
+					name, value := d.value("name"), d.value("defaultValue")
+					if value == "" || d.XMLName.Local == "hudson.model.BooleanParameterDefinition" {
+						continue
+					}
+					// encrypted secrets are stored as {base64}
+					encrypted := strings.HasPrefix(value, "{") && strings.HasSuffix(value, "}")
+					switch {
+					case d.XMLName.Local == "hudson.model.PasswordParameterDefinition" && !encrypted:
+						problems = append(problems, fmt.Sprintf("password parameter %s has a plain-text default value", name))
+					case d.XMLName.Local != "hudson.model.PasswordParameterDefinition" && rule.pattern.MatchString(name):
+						problems = append(problems, fmt.Sprintf("parameter %s looks like a secret stored in plain text, use a password parameter or credentials", name))
+					}
+				}
+				return problems
+			},
+		},
+		{
+			ID:          "no-scm",
+			Description: "job is not backed by a source repository",
+			Severity:    SeverityWarning,
+			check: func(rule *LintRule, root *xmlNode) []string {
+				if root.XMLName.Local == "flow-definition" {
+					if root.child("definition").attr("class") == "org.jenkinsci.plugins.workflow.cps.CpsFlowDefinition" {
+						return []string{"pipeline script is inline, not loaded from scm"}
+					}
+					return nil
+				}
+				scm := root.child("scm")
+				if scm == nil || scm.attr("class") == "hudson.scm.NullSCM" {
+					return []string{"job uses NullSCM"}
+				}
+				return nil
+			},
+		},
+	}
+}
+
+// LoadLintRules will return the enabled rules, the defaults are
+// changed by the rules file, i.e:
+//
+//	rules:
+//	  missing-description:
+//	    enabled: false
+//	  no-scm:
+//	    severity: error
+//	  plain-text-password:
+//	    pattern: (?i)pass|token
+//
+// Args:
+//	path - rules file, empty for the defaults
+//	disabled - rule ids to disable
+//
+// Returns
+//	list of rules, nil or error
+func LoadLintRules(path string, disabled []string) ([]*LintRule, error) {
+	rules := DefaultLintRules()
+	byID := map[string]*LintRule{}
+	for _, r := range rules {
+		r.pattern = regexp.MustCompile(defaultPasswordPattern)
+		byID[r.ID] = r
+	}
+
+	off := map[string]bool{}
+	for _, id := range disabled {
+		if byID[id] == nil {
+			return nil, fmt.Errorf("unknown rule: %s", id)
+		}
+		off[id] = true
+	}
+
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		config := struct {
+			Rules map[string]LintRuleConfig `yaml:"rules"`
+		}{}
+		if err = yaml.UnmarshalStrict(data, &config); err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+		for id, c := range config.Rules {
+			rule := byID[id]
+			if rule == nil {
+				return nil, fmt.Errorf("%s: unknown rule: %s", path, id)
+			}
+			if c.Enabled != nil && !*c.Enabled {
+				off[id] = true
+			}
+			switch c.Severity {
+			case "":
+			case SeverityError, SeverityWarning:
+				rule.Severity = c.Severity
+			default:
+				return nil, fmt.Errorf("%s: rule %s has unknown severity: %s (use error or warning)", path, id, c.Severity)
+			}
+			if c.Pattern != "" {
+				if id != "plain-text-password" {
+					return nil, fmt.Errorf("%s: rule %s has no pattern", path, id)
+				}
+				if rule.pattern, err = regexp.Compile(c.Pattern); err != nil {
+					return nil, fmt.Errorf("%s: rule %s: %s", path, id, err)
+				}
+			}
+		}
+	}
+
+	var enabled []*LintRule
+	for _, r := range rules {
+		if !off[r.ID] {
+			enabled = append(enabled, r)
+		}
+	}
+	return enabled, nil
+}
+
+// LintConfig will check a job config.xml against the rules
+//
+// Args:
+//	job - job name or file, used in the result
+//	config - job config.xml
+//	rules - rules to check
+//
+// Returns
+//	lint result
+func LintConfig(job string, config string, rules []*LintRule) LintResult {
+	result := LintResult{Job: job, Findings: []LintFinding{}}
+	root := &xmlNode{}
+	if err := xml.Unmarshal([]byte(stripXMLDeclaration(config)), root); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	for _, rule := range rules {
+		for _, message := range rule.check(rule, root) {
+			result.Findings = append(result.Findings, LintFinding{Rule: rule.ID, Severity: rule.Severity, Message: message})
+		}
+	}
+	return result
+}
+
+// LintFiles will check job files, XML, templates rendered without
+// values and YAML job specs
+//
+// Args:
+//	paths - job files
+//	rules - rules to check
+//
+// Returns
+//	lint results
+func LintFiles(paths []string, rules []*LintRule) []LintResult {
+	results := make([]LintResult, len(paths))
+	for i, path := range paths {
+		config, err := LoadJobConfig(path, nil, nil)
+		if err != nil {
+			results[i] = LintResult{Job: path, Error: err.Error(), Findings: []LintFinding{}}
+			continue
+		}
+		results[i] = LintConfig(path, config, rules)
+	}
+	return results
+}
+
+// LintServerJobs will check the config.xml of all jobs in the server
+//
+// Args:
+//	folder - folder full name, empty for all jobs
+//	rules - rules to check
+//	workers - max number of concurrent requests
+//
+// Returns
+//	lint results, nil or error
+func (j *Jenkins) LintServerJobs(folder string, rules []*LintRule, workers int) ([]LintResult, error) {
+	jobs, err := j.ListJobs(folder)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(jobs, func(a, b int) bool { return jobs[a].FullName < jobs[b].FullName })
+
+	results := make([]LintResult, len(jobs))
+	runParallel(len(jobs), workers, func(i int) {
+		config, err := j.getJobConfig(jobs[i].FullName)
+		if err != nil {
+			results[i] = LintResult{Job: jobs[i].FullName, Error: err.Error(), Findings: []LintFinding{}}
+			return
+		}
+		results[i] = LintConfig(jobs[i].FullName, config, rules)
+	})
+	return results, nil
+}
+
+// LintFailed returns true when a config could not be read or a rule with
+// error severity was violated
+func LintFailed(results []LintResult) bool {
+	for _, r := range results {
+		if r.Error != "" {
+			return true
+		}
+		for _, f := range r.Findings {
+			if f.Severity == SeverityError {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// junitTestSuite is the JUnit XML report, a test case per job and rule
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteLintReport will write the lint results as text, json or junit
+//
+// Args:
+//	out - where the report is written
+//	results - lint results
+//	rules - rules checked, used for the junit test cases
+//	format - text, json or junit
+//
+// Returns
+//	nil or error
+func WriteLintReport(out io.Writer, results []LintResult, rules []*LintRule, format string) error {
+	switch format {
+	case "json":
+		if results == nil {
+			results = []LintResult{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case "junit":
+		suite := junitTestSuite{Name: "jenkinsctl lint"}
+		for _, r := range results {
+			if r.Error != "" {
+				suite.Errors++
+				suite.TestCases = append(suite.TestCases, junitTestCase{
+					ClassName: r.Job,
+					Name:      "config",
+					Error:     &junitMessage{Message: r.Error, Type: "error", Text: r.Error},
+				})
+				continue
+			}
+			for _, rule := range rules {
+				tc := junitTestCase{ClassName: r.Job, Name: rule.ID}
+				var messages []string
+				for _, f := range r.Findings {
+					if f.Rule == rule.ID {
+						messages = append(messages, f.Message)
+					}
+				}
+				if len(messages) > 0 {
+					suite.Failures++
+					tc.Failure = &junitMessage{Message: messages[0], Type: rule.Severity, Text: strings.Join(messages, "\n")}
+				}
+				suite.TestCases = append(suite.TestCases, tc)
+			}
+		}
+		suite.Tests = len(suite.TestCases)
+		data, err := xml.MarshalIndent(suite, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "%s%s\n", xml.Header, data)
+		return err
+	case "text":
+		errors, warnings := 0, 0
+		for _, r := range results {
+			if r.Error != "" {
+				errors++
+				fmt.Fprintf(out, "❌ %s: %s\n", r.Job, r.Error)
+				continue
+			}
+			for _, f := range r.Findings {
+				icon := "⚠️ "
+				if f.Severity == SeverityError {
+					icon = "❌"
+					errors++
+				} else {
+					warnings++
+				}
+				fmt.Fprintf(out, "%s %s: [%s] %s\n", icon, r.Job, f.Rule, f.Message)
+			}
+		}
+		fmt.Fprintf(out, "\nJobs checked: %d, errors: %d, warnings: %d\n", len(results), errors, warnings)
+		return nil
+	}
+	return fmt.Errorf("unknown format: %s (use text, json or junit)", format)
+}
@@ -0,0 +1,215 @@
+package jenkins
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Classes of the items that compute their jobs from a repository
+const (
+	MultibranchClass        = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+	OrganizationFolderClass = "jenkins.branch.OrganizationFolder"
+)
+
+// scanStartTimeout is how long ScanMultibranch waits for the scan to start
+const scanStartTimeout = 2 * time.Minute
+
+// changeRequest matches the names of pull and merge request jobs when the
+// change-requests view is not available
+var changeRequest = regexp.MustCompile(`^(PR|MR)-\d+$`)
+
+// multibranchKind returns a readable kind for multibranch projects and
+// organization folders, empty for other classes
+func multibranchKind(class string) string {
+	switch class {
+	case MultibranchClass:
+		return "multibranch"
+	case OrganizationFolderClass:
+		return "organization folder"
+	}
+	return ""
+}
+
+// BranchInfo is a branch, pull request or tag job of a multibranch project
+type BranchInfo struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	FullName    string `json:"fullName"`
+	Color       string `json:"color"`
+	Kind        string `json:"kind"`
+	LastBuild   *struct {
+		Number    int64  `json:"number"`
+		Result    string `json:"result"`
+		Timestamp int64  `json:"timestamp"`
+	} `json:"lastBuild"`
+}
+
+// GetBranches will get the branch, pull request and tag jobs of a
+// multibranch project with a single request
+//
+// Args:
+//	jobName - multibranch project full name
+//
+// Returns
+//	list of branches, nil or error
+func (j *Jenkins) GetBranches(jobName string) ([]*BranchInfo, error) {
+	response := struct {
+		Class string        `json:"_class"`
+		Jobs  []*BranchInfo `json:"jobs"`
+		Views []struct {
+			Name string `json:"name"`
+			Jobs []struct {
+				Name string `json:"name"`
+			} `json:"jobs"`
+		} `json:"views"`
+	}{}
+	tree := "_class,jobs[name,displayName,fullName,color,lastBuild[number,result,timestamp]],views[name,jobs[name]]"
+	err := j.getJSON(jobBase(jobName), &response, map[string]string{"tree": tree})
+	if err != nil {
+		return nil, err
+	}
+	if response.Class != MultibranchClass {
+		return nil, fmt.Errorf("%s is not a multibranch project (%s)", jobName, response.Class)
+	}
+
+	// the multibranch views group the jobs by kind
+	kinds := map[string]string{}
+	viewKinds := map[string]string{"default": "branch", "change-requests": "pull request", "tags": "tag"}
+	for _, v := range response.Views {
+		for _, job := range v.Jobs {
+			if kind, ok := viewKinds[v.Name]; ok {
+				kinds[job.Name] = kind
+			}
+		}
+	}
+	for _, b := range response.Jobs {
+		b.Kind = kinds[b.Name]
+		if b.Kind == "" {
+			b.Kind = "branch"
+			if changeRequest.MatchString(b.Name) {
+				b.Kind = "pull request"
+			}
+		}
+	}
+	sort.Slice(response.Jobs, func(a, b int) bool {
+		if response.Jobs[a].Kind != response.Jobs[b].Kind {
+			return response.Jobs[a].Kind < response.Jobs[b].Kind
+		}
+		return response.Jobs[a].DisplayName < response.Jobs[b].DisplayName
+	})
+	return response.Jobs, nil
+}
+
+// ShowBranches will show the branch, pull request and tag jobs of a
+// multibranch project with the last build status. The job column is the
+// name to use in the other commands, i.e: app/feature%2Flogin
+//
+// Args:
+//	jobName - multibranch project full name
+//
+// Returns
+//	nil or error
+func (j *Jenkins) ShowBranches(jobName string) error {
+	branches, err := j.GetBranches(jobName)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "BRANCH\tKIND\tLAST BUILD\tRESULT\tSTARTED\tJOB\n")
+	for _, b := range branches {
+		number, result, started := "-", "-", "-"
+		if b.LastBuild != nil {
+			number = fmt.Sprintf("#%d", b.LastBuild.Number)
+			result = b.LastBuild.Result
+			if result == "" {
+				result = "IN_PROGRESS"
+			}
+			started = time.Unix(0, b.LastBuild.Timestamp*int64(time.Millisecond)).Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s %s\t%s\t%s\n",
+			b.DisplayName, b.Kind, number, statusIcons[result], result, started, b.FullName)
+	}
+	w.Flush()
+	fmt.Printf("\nNumber of branches: %d\n", len(branches))
+	return nil
+}
+
+// ScanMultibranch will start the branch indexing of a multibranch
+// project, or the scan of an organization folder, and write the log
+// until the scan finishes
+//
+// Args:
+//	out - where the scan log is written
+//	jobName - multibranch project or organization folder full name
+//
+// Returns
+//	nil or error
+func (j *Jenkins) ScanMultibranch(out io.Writer, jobName string) error {
+	item := struct {
+		Class string `json:"_class"`
+	}{}
+	err := j.getJSON(jobBase(jobName), &item, map[string]string{"tree": "_class"})
+	if err != nil {
+		return err
+	}
+	var endpoint string
+	switch item.Class {
+	case MultibranchClass:
+		endpoint = jobBase(jobName) + "/indexing/logText/progressiveText"
+	case OrganizationFolderClass:
+		endpoint = jobBase(jobName) + "/computation/logText/progressiveText"
+	default:
+		return fmt.Errorf("%s is not a multibranch project or organization folder (%s)", jobName, item.Class)
+	}
+
+	// the previous scan log is used to find when the new scan starts
+	var previous strings.Builder
+	if _, _, err = j.readProgressiveLog(&previous, endpoint, 0); err != nil {
+		return err
+	}
+
+	if err = j.postForm(jobBase(jobName)+"/build", url.Values{"delay": {"0"}}); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(scanStartTimeout)
+	for {
+		var current strings.Builder
+		_, more, err := j.readProgressiveLog(&current, endpoint, 0)
+		if err != nil {
+			return err
+		}
+		if more || current.String() != previous.String() {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the scan of %s did not start after %s", jobName, scanStartTimeout)
+		}
+		if err = j.sleep(pollInterval); err != nil {
+			return err
+		}
+	}
+
+	var start int64
+	for {
+		var more bool
+		start, more, err = j.readProgressiveLog(out, endpoint, start)
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+		if err = j.sleep(pollInterval); err != nil {
+			return err
+		}
+	}
+}
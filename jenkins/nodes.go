@@ -0,0 +1,214 @@
+package jenkins
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// executorTree is the tree= query used to collect executor usage from a node
+const executorTree = "displayName,offline,temporarilyOffline,offlineCauseReason,numExecutors," +
+	"assignedLabels[name]," +
+	"executors[idle,number,progress,currentExecutable[fullDisplayName,number,url,timestamp]]," +
+	"oneOffExecutors[idle,number,progress,currentExecutable[fullDisplayName,number,url,timestamp]]"
+
+// ExecutorInfo is a single executor slot of a node
+type ExecutorInfo struct {
+	Idle              bool `json:"idle"`
+	Number            int  `json:"number"`
+	Progress          int  `json:"progress"`
+	CurrentExecutable *struct {
+		FullDisplayName string `json:"fullDisplayName"`
+		Number          int64  `json:"number"`
+		URL             string `json:"url"`
+		Timestamp       int64  `json:"timestamp"`
+	} `json:"currentExecutable"`
+}
+
+// NodeExecutors is the executor usage of a node
+type NodeExecutors struct {
+	DisplayName        string `json:"displayName"`
+	Offline            bool   `json:"offline"`
+	TemporarilyOffline bool   `json:"temporarilyOffline"`
+	OfflineCauseReason string `json:"offlineCauseReason"`
+	NumExecutors       int    `json:"numExecutors"`
+	AssignedLabels     []struct {
+		Name string `json:"name"`
+	} `json:"assignedLabels"`
+	Executors       []ExecutorInfo `json:"executors"`
+	OneOffExecutors []ExecutorInfo `json:"oneOffExecutors"`
+}
+
+// Busy returns the number of regular executors running a build
+func (n *NodeExecutors) Busy() int {
+	busy := 0
+	for _, e := range n.Executors {
+		if !e.Idle {
+			busy++
+		}
+	}
+	return busy
+}
+
+// Labels returns the labels of the node, without the node self label
+func (n *NodeExecutors) Labels() []string {
+	var labels []string
+	for _, l := range n.AssignedLabels {
+		if l.Name != n.DisplayName {
+			labels = append(labels, l.Name)
+		}
+	}
+	return labels
+}
+
+// loadStatistics is the overallLoad statistics of the server
+type loadStatistics struct {
+	QueueLength struct {
+		Min struct {
+			Latest float64 `json:"latest"`
+		} `json:"min"`
+	} `json:"queueLength"`
+}
+
+// nodeStatusTree is the tree= query used to list the nodes status
+const nodeStatusTree = "computer[displayName,offline,temporarilyOffline,idle,offlineCauseReason]"
+
+// NodeStatus is the online status of a node
+type NodeStatus struct {
+	DisplayName        string `json:"displayName"`
+	Offline            bool   `json:"offline"`
+	TemporarilyOffline bool   `json:"temporarilyOffline"`
+	Idle               bool   `json:"idle"`
+	OfflineCauseReason string `json:"offlineCauseReason"`
+}
+
+// GetNodeStatus will collect the status of all nodes with a single
+// request
+//
+// Args:
+//
+// Returns
+//	list of nodes status, nil or error
+func (j *Jenkins) GetNodeStatus() ([]*NodeStatus, error) {
+	response := struct {
+		Computer []*NodeStatus `json:"computer"`
+	}{}
+	err := j.getJSON("/computer", &response, map[string]string{"tree": nodeStatusTree})
+	if err != nil {
+		return nil, err
+	}
+	return response.Computer, nil
+}
+
+// GetNodeExecutors will collect the executor usage from all nodes with a
+// single request
+//
+// Args:
+//
+// Returns
+//	list of nodes executors, nil or error
+func (j *Jenkins) GetNodeExecutors() ([]*NodeExecutors, error) {
+	response := struct {
+		Computer []*NodeExecutors `json:"computer"`
+	}{}
+	err := j.getJSON("/computer", &response, map[string]string{"tree": "computer[" + executorTree + "]"})
+	if err != nil {
+		return nil, err
+	}
+	return response.Computer, nil
+}
+
+// ShowNodeExecutors will show busy and total executors per node and label,
+// the builds running in each executor and the queue length
+//
+// Args:
+//
+// Returns
+//	nil or error
+func (j *Jenkins) ShowNodeExecutors() error {
+	nodes, err := j.GetNodeExecutors()
+	if err != nil {
+		return err
+	}
+
+	type labelUsage struct {
+		busy  int
+		total int
+	}
+	labels := map[string]*labelUsage{}
+	busyTotal, executorsTotal := 0, 0
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "NODE\tSTATUS\tBUSY/TOTAL\tLABELS\n")
+	for _, node := range nodes {
+		status := "✅ online"
+		if node.Offline || node.TemporarilyOffline {
+			status = "❌ offline"
+		}
+		busy := node.Busy()
+		busyTotal += busy
+		executorsTotal += node.NumExecutors
+		for _, l := range node.Labels() {
+			if labels[l] == nil {
+				labels[l] = &labelUsage{}
+			}
+			labels[l].busy += busy
+			labels[l].total += node.NumExecutors
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d/%d\t%s\n", node.DisplayName, status, busy, node.NumExecutors, strings.Join(node.Labels(), " "))
+	}
+	fmt.Fprintf(w, "TOTAL\t\t%d/%d\t\n", busyTotal, executorsTotal)
+	w.Flush()
+
+	if len(labels) > 0 {
+		names := make([]string, 0, len(labels))
+		for l := range labels {
+			names = append(names, l)
+		}
+		sort.Strings(names)
+
+		fmt.Printf("\n")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "LABEL\tBUSY/TOTAL\n")
+		for _, l := range names {
+			fmt.Fprintf(w, "%s\t%d/%d\n", l, labels[l].busy, labels[l].total)
+		}
+		w.Flush()
+	}
+
+	fmt.Printf("\n")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "NODE\tEXECUTOR\tBUILD\tRUNNING FOR\n")
+	running := 0
+	for _, node := range nodes {
+		executors := append(append([]ExecutorInfo{}, node.Executors...), node.OneOffExecutors...)
+		for _, e := range executors {
+			if e.Idle || e.CurrentExecutable == nil {
+				continue
+			}
+			started := time.Unix(0, e.CurrentExecutable.Timestamp*int64(time.Millisecond))
+			fmt.Fprintf(w, "%s\t#%d\t%s\t%s\n",
+				node.DisplayName,
+				e.Number,
+				e.CurrentExecutable.FullDisplayName,
+				time.Since(started).Round(time.Second))
+			running++
+		}
+	}
+	w.Flush()
+	if running == 0 {
+		fmt.Printf("😴 No builds running\n")
+	}
+
+	load := loadStatistics{}
+	err = j.getJSON("/overallLoad", &load, map[string]string{"tree": "queueLength[min[latest]]"})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n⏳ Queue length: %.2f\n", load.QueueLength.Min.Latest)
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// validateSuccess is the response of the declarative linter for a valid
+// Jenkinsfile
+const validateSuccess = "successfully validated"
+
+// validateError matches the errors of the declarative linter, i.e:
+// WorkflowScript: 4: Expected a step @ line 4, column 19.
+var validateError = regexp.MustCompile(`^WorkflowScript: (\d+): (.*?)(?: @ line (\d+), column (\d+)\.)?$`)
+
+// PipelineError is a Jenkinsfile error found by the declarative linter,
+// Column is zero when unknown
+type PipelineError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// ValidateJenkinsfile will check a declarative Jenkinsfile with the
+// pipeline-model-converter/validate endpoint
+//
+// Args:
+//	jenkinsfile - content of the Jenkinsfile
+//
+// Returns
+//	list of errors, empty when valid, nil or error
+func (j *Jenkins) ValidateJenkinsfile(jenkinsfile string) ([]PipelineError, error) {
+	endpoint := "/pipeline-model-converter/validate"
+	values := url.Values{"jenkinsfile": {jenkinsfile}}
+
+	response, resp, err := j.postText(endpoint, strings.NewReader(values.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("%s not found, is the pipeline-model-definition plugin installed?", endpoint)
+	}
+	if err = checkStatus(resp, endpoint); err != nil {
+		return nil, err
+	}
+	if strings.Contains(response, validateSuccess) {
+		return []PipelineError{}, nil
+	}
+
+	var errs []PipelineError
+	for _, line := range strings.Split(response, "\n") {
+		m := validateError.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		e := PipelineError{Message: m[2]}
+		e.Line, _ = strconv.Atoi(m[1])
+		if m[3] != "" {
+			e.Line, _ = strconv.Atoi(m[3])
+			e.Column, _ = strconv.Atoi(m[4])
+		}
+		errs = append(errs, e)
+	}
+	if len(errs) == 0 {
+		// unknown format, keep the whole response
+		errs = append(errs, PipelineError{Message: strings.TrimSpace(response)})
+	}
+	return errs, nil
+}
+
+// InputParameter is a parameter of a pending input step
+type InputParameter struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Definition  struct {
+		DefaultParameterValue *struct {
+			Value interface{} `json:"value"`
+		} `json:"defaultParameterValue"`
+		Choices []string `json:"choices"`
+	} `json:"definition"`
+}
+
+// Default returns the default value of the parameter, empty if none
+func (p *InputParameter) Default() string {
+	if p.Definition.DefaultParameterValue == nil || p.Definition.DefaultParameterValue.Value == nil {
+		return ""
+	}
+	return fmt.Sprint(p.Definition.DefaultParameterValue.Value)
+}
+
+// PendingInput is an input step waiting for approval
+type PendingInput struct {
+	ID          string           `json:"id"`
+	Message     string           `json:"message"`
+	ProceedText string           `json:"proceedText"`
+	Inputs      []InputParameter `json:"inputs"`
+}
+
+// GetPendingInputs will get the input steps waiting for approval in a
+// pipeline build
+//
+// Args:
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//
+// Returns
+//	list of pending inputs, nil or error
+func (j *Jenkins) GetPendingInputs(jobName string, build string) ([]PendingInput, error) {
+	inputs := []PendingInput{}
+	endpoint := buildBase(jobName, build) + "/wfapi/pendingInputActions"
+	resp, err := j.Instance.Requester.Get(j.Context, endpoint, &inputs, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("build %s of %s not found or it is not a pipeline", build, jobName)
+	}
+	if err = checkStatus(resp, endpoint); err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+// ShowPendingInputs will show the input steps waiting for approval with
+// their message and parameters
+//
+// Args:
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//
+// Returns
+//	nil or error
+func (j *Jenkins) ShowPendingInputs(jobName string, build string) error {
+	inputs, err := j.GetPendingInputs(jobName, build)
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 0 {
+		fmt.Printf("No pending inputs\n")
+		return nil
+	}
+
+	for _, input := range inputs {
+		fmt.Printf("⏸️  %s\n", input.ID)
+		fmt.Printf("  Message: %s\n", input.Message)
+		fmt.Printf("  Proceed: %s\n", input.ProceedText)
+		if len(input.Inputs) == 0 {
+			continue
+		}
+		fmt.Printf("  Parameters:\n")
+		for _, p := range input.Inputs {
+			fmt.Printf("    %s (%s)", p.Name, strings.TrimSuffix(p.Type, "ParameterDefinition"))
+			if p.Default() != "" {
+				fmt.Printf(" default: %s", p.Default())
+			}
+			if len(p.Definition.Choices) > 0 {
+				fmt.Printf(" choices: %s", strings.Join(p.Definition.Choices, ", "))
+			}
+			if p.Description != "" {
+				fmt.Printf(" - %s", p.Description)
+			}
+			fmt.Printf("\n")
+		}
+	}
+	return nil
+}
+
+// findInput returns the pending input with id, Jenkins capitalizes the
+// input ids so the match is case insensitive
+func (j *Jenkins) findInput(jobName string, build string, inputID string) (*PendingInput, error) {
+	inputs, err := j.GetPendingInputs(jobName, build)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for i := range inputs {
+		if strings.EqualFold(inputs[i].ID, inputID) {
+			return &inputs[i], nil
+		}
+		ids = append(ids, inputs[i].ID)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no pending inputs in build %s of %s", build, jobName)
+	}
+	return nil, fmt.Errorf("input %s not found, pending inputs: %s", inputID, strings.Join(ids, ", "))
+}
+
+// ProceedInput will approve a pending input step, parameters not given
+// use the default value
+//
+// Args:
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//	inputID - input step id
+//	params - parameter values
+//
+// Returns
+//	nil or error
+func (j *Jenkins) ProceedInput(jobName string, build string, inputID string, params map[string]string) error {
+	input, err := j.findInput(jobName, build, inputID)
+	if err != nil {
+		return err
+	}
+	endpoint := buildBase(jobName, build) + "/input/" + url.PathEscape(input.ID)
+
+	if len(input.Inputs) == 0 {
+		if len(params) > 0 {
+			return fmt.Errorf("input %s has no parameters", input.ID)
+		}
+		return j.postForm(endpoint+"/proceedEmpty", url.Values{})
+	}
+
+	type parameter struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	known := map[string]bool{}
+	values := []parameter{}
+	for _, p := range input.Inputs {
+		known[p.Name] = true
+		value, ok := params[p.Name]
+		if !ok {
+			value = p.Default()
+		}
+		values = append(values, parameter{Name: p.Name, Value: value})
+	}
+	for name := range params {
+		if !known[name] {
+			return fmt.Errorf("input %s has no parameter %s", input.ID, name)
+		}
+	}
+
+	data, err := json.Marshal(map[string][]parameter{"parameter": values})
+	if err != nil {
+		return err
+	}
+	return j.postForm(endpoint+"/submit", url.Values{
+		"json":    {string(data)},
+		"proceed": {input.ProceedText},
+	})
+}
+
+// AbortInput will reject a pending input step, the build is aborted
+//
+// Args:
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//	inputID - input step id
+//
+// Returns
+//	nil or error
+func (j *Jenkins) AbortInput(jobName string, build string, inputID string) error {
+	input, err := j.findInput(jobName, build, inputID)
+	if err != nil {
+		return err
+	}
+	return j.postForm(buildBase(jobName, build)+"/input/"+url.PathEscape(input.ID)+"/abort", url.Values{})
+}
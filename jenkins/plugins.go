@@ -0,0 +1,711 @@
+package jenkins
+
+import (
+	"archive/zip"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// PluginUpdate is a plugin with a newer version available in the update center
+type PluginUpdate struct {
+	Name      string
+	LongName  string
+	Current   string
+	Available string
+}
+
+// updateSite is the update center metadata of the default site
+type updateSite struct {
+	Updates []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"updates"`
+}
+
+// GetOutdatedPlugins will list the installed plugins with an update
+// available, the version is taken from the update center metadata
+//
+// Returns
+//	list of plugin updates, nil or error
+func (j *Jenkins) GetOutdatedPlugins() ([]PluginUpdate, error) {
+	plugins, err := j.Instance.GetPlugins(j.Context, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	site := updateSite{}
+	err = j.getJSON("/updateCenter/site/default", &site, map[string]string{"tree": "updates[name,version]"})
+	if err != nil {
+		return nil, err
+	}
+	available := map[string]string{}
+	for _, u := range site.Updates {
+		available[u.Name] = u.Version
+	}
+
+	var updates []PluginUpdate
+	for _, p := range plugins.Raw.Plugins {
+		if !p.HasUpdate {
+			continue
+		}
+		version := available[p.ShortName]
+		if version == "" {
+			version = "unknown"
+		}
+		updates = append(updates, PluginUpdate{
+			Name:      p.ShortName,
+			LongName:  p.LongName,
+			Current:   p.Version,
+			Available: version,
+		})
+	}
+	sort.Slice(updates, func(a, b int) bool { return updates[a].Name < updates[b].Name })
+	return updates, nil
+}
+
+// ShowOutdatedPlugins show the installed plugins with an update available
+//
+// Returns
+//	nil or error
+func (j *Jenkins) ShowOutdatedPlugins() error {
+	updates, err := j.GetOutdatedPlugins()
+	if err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		fmt.Printf("✅ All plugins are up to date\n")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "PLUGIN\tCURRENT\tAVAILABLE\n")
+	for _, u := range updates {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", u.Name, u.Current, u.Available)
+	}
+	w.Flush()
+	fmt.Printf("\nNumber of outdated plugins: %d\n", len(updates))
+	return nil
+}
+
+// UpgradePlugins will install the available update of plugins
+//
+// Args:
+//	names - plugins to upgrade, ignored when all is true
+//	all - upgrade all outdated plugins
+//
+// Returns
+//	number of plugins upgraded, nil or error
+func (j *Jenkins) UpgradePlugins(names []string, all bool) (int, error) {
+	if !all && len(names) == 0 {
+		return 0, errors.New("❌ requires plugin names or --all")
+	}
+
+	updates, err := j.GetOutdatedPlugins()
+	if err != nil {
+		return 0, err
+	}
+	outdated := map[string]PluginUpdate{}
+	for _, u := range updates {
+		outdated[u.Name] = u
+	}
+
+	var selected []PluginUpdate
+	if all {
+		selected = updates
+	} else {
+		for _, name := range names {
+			u, ok := outdated[name]
+			if !ok {
+				fmt.Printf("✅ %s is up to date or not installed\n", name)
+				continue
+			}
+			selected = append(selected, u)
+		}
+	}
+
+	upgraded := 0
+	for _, u := range selected {
+		if u.Available == "unknown" {
+			fmt.Printf("❌ %s: no version available in the update center\n", u.Name)
+			continue
+		}
+		fmt.Printf("⏳ Upgrading %s %s -> %s...\n", u.Name, u.Current, u.Available)
+		err = j.installPlugin(u.Name, u.Available)
+		if err != nil {
+			return upgraded, fmt.Errorf("cannot upgrade plugin %s: %s", u.Name, err)
+		}
+		upgraded++
+	}
+	fmt.Printf("Number of plugins upgraded: %d\n", upgraded)
+	return upgraded, nil
+}
+
+// SafeRestart will restart jenkins when there are no jobs running
+//
+// Returns
+//	nil or error
+func (j *Jenkins) SafeRestart() error {
+	err := j.Instance.SafeRestart(j.Context)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("⏳ Jenkins will restart once all running jobs are finished\n")
+	return nil
+}
+
+// PluginRequirement is an entry of a plugins.txt file
+type PluginRequirement struct {
+	Name    string
+	Version string
+}
+
+// ExportPlugins will write the installed plugins in the name:version
+// format used by jenkins-plugin-cli
+//
+// Args:
+//	out - where the plugins list is written
+//
+// Returns
+//	nil or error
+func (j *Jenkins) ExportPlugins(out io.Writer) error {
+	plugins, err := j.Instance.GetPlugins(j.Context, 1)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	for _, p := range plugins.Raw.Plugins {
+		if p.Deleted {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s:%s", p.ShortName, p.Version))
+	}
+	sort.Strings(lines)
+	for _, l := range lines {
+		fmt.Fprintln(out, l)
+	}
+	return nil
+}
+
+// ReadPluginsFile will parse a plugins.txt file, empty lines and
+// comments (#) are ignored. A missing version or "latest" means any
+// version is accepted
+//
+// Args:
+//	path - path to plugins.txt
+//
+// Returns
+//	list of plugin requirements, nil or error
+func ReadPluginsFile(path string) ([]PluginRequirement, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var plugins []PluginRequirement
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		fields := strings.SplitN(text, ":", 3)
+		p := PluginRequirement{Name: strings.TrimSpace(fields[0])}
+		if len(fields) > 1 {
+			p.Version = strings.TrimSpace(fields[1])
+		}
+		if p.Version == "latest" {
+			p.Version = ""
+		}
+		if p.Name == "" {
+			return nil, fmt.Errorf("%s:%d: missing plugin name", path, line)
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins, scanner.Err()
+}
+
+// InstallPluginsFile will compare the installed plugins with a plugins.txt
+// file, report the drift and install the missing plugins and the plugins
+// with a different version at the pinned versions
+//
+// Args:
+//	path - path to plugins.txt
+//	check - only report the drift, do not install anything
+//
+// Returns
+//	number of differences found, nil or error
+func (j *Jenkins) InstallPluginsFile(path string, check bool) (int, error) {
+	wanted, err := ReadPluginsFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	plugins, err := j.Instance.GetPlugins(j.Context, 1)
+	if err != nil {
+		return 0, err
+	}
+	installed := map[string]string{}
+	for _, p := range plugins.Raw.Plugins {
+		if !p.Deleted {
+			installed[p.ShortName] = p.Version
+		}
+	}
+
+	drift := 0
+	inFile := map[string]bool{}
+	var install []PluginRequirement
+	for _, p := range wanted {
+		inFile[p.Name] = true
+		version, ok := installed[p.Name]
+		switch {
+		case !ok:
+			fmt.Printf("❌ %s: not installed (wanted %s)\n", p.Name, displayVersion(p.Version))
+			install = append(install, p)
+			drift++
+		case p.Version != "" && p.Version != version:
+			fmt.Printf("⚠️  %s: version differs (server %s, file %s)\n", p.Name, version, p.Version)
+			install = append(install, p)
+			drift++
+		}
+	}
+
+	var extra []string
+	for name := range installed {
+		if !inFile[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		fmt.Printf("⚠️  %s: installed (%s) but not in %s\n", name, installed[name], path)
+		drift++
+	}
+
+	if drift == 0 {
+		fmt.Printf("✅ Server plugins match %s\n", path)
+	}
+	if check {
+		return drift, nil
+	}
+
+	for _, p := range install {
+		// installNecessaryPlugins always installs the update center
+		// release, pinned versions are downloaded and uploaded
+		if p.Version == "" {
+			fmt.Printf("⏳ Installing %s latest...\n", p.Name)
+			err = j.installPlugin(p.Name, "latest")
+		} else {
+			fmt.Printf("⏳ Installing %s %s...\n", p.Name, p.Version)
+			err = j.installPinnedPlugin(p.Name, p.Version)
+		}
+		if err != nil {
+			return drift, fmt.Errorf("cannot install plugin %s: %s", p.Name, err)
+		}
+	}
+	if len(install) > 0 {
+		fmt.Printf("Number of plugins installed: %d\n", len(install))
+	}
+	return drift, nil
+}
+
+// pluginDownloadURL is where the releases of a plugin are downloaded from,
+// the arguments are name, version and name
+const pluginDownloadURL = "https://updates.jenkins.io/download/plugins/%s/%s/%s.hpi"
+
+// installPlugin will install a plugin from the update center, it is always
+// the release available in the update center
+//
+// Args:
+//	name - plugin short name
+//	version - plugin version, i.e: latest
+//
+// Returns
+//	nil or error
+func (j *Jenkins) installPlugin(name string, version string) error {
+	endpoint := "/pluginManager/installNecessaryPlugins"
+	xml := fmt.Sprintf(`<jenkins><install plugin="%s@%s" /></jenkins>`, name, version)
+	resp, err := j.Instance.Requester.PostXML(j.Context, endpoint, xml, nil, nil)
+	if err != nil {
+		return err
+	}
+	return checkStatus(resp, endpoint)
+}
+
+// installPinnedPlugin will download a release of a plugin and upload it to
+// the server, a running version is replaced after a restart
+//
+// Args:
+//	name - plugin short name
+//	version - plugin version
+//
+// Returns
+//	nil or error
+func (j *Jenkins) installPinnedPlugin(name string, version string) error {
+	dir, err := ioutil.TempDir("", "jenkinsctl-plugin-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	source := fmt.Sprintf(pluginDownloadURL, url.PathEscape(name), url.PathEscape(version), url.PathEscape(name))
+	req, err := http.NewRequest("GET", source, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := j.Instance.Requester.Client.Do(req.WithContext(j.Context))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err = checkStatus(resp, source); err != nil {
+		return err
+	}
+
+	file := filepath.Join(dir, name+".hpi")
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return j.UploadPlugin(file)
+}
+
+// displayVersion returns the version or "any version" when not pinned
+func displayVersion(version string) string {
+	if version == "" {
+		return "any version"
+	}
+	return version
+}
+
+// pluginDependency is a dependency of an installed plugin
+type pluginDependency struct {
+	ShortName string `json:"shortName"`
+	Version   string `json:"version"`
+	Optional  bool   `json:"optional"`
+}
+
+// pluginInfo is an installed plugin with its dependencies. gojenkins
+// decodes the optional field as string so the dependencies are read here
+type pluginInfo struct {
+	ShortName    string             `json:"shortName"`
+	LongName     string             `json:"longName"`
+	Version      string             `json:"version"`
+	Active       bool               `json:"active"`
+	Enabled      bool               `json:"enabled"`
+	Deleted      bool               `json:"deleted"`
+	Dependencies []pluginDependency `json:"dependencies"`
+}
+
+// getPluginGraph will collect the installed plugins (depth 1) indexed by
+// short name
+//
+// Returns
+//	map of plugins, nil or error
+func (j *Jenkins) getPluginGraph() (map[string]*pluginInfo, error) {
+	response := struct {
+		Plugins []*pluginInfo `json:"plugins"`
+	}{}
+	err := j.getJSON("/pluginManager", &response, map[string]string{"depth": "1"})
+	if err != nil {
+		return nil, err
+	}
+
+	graph := map[string]*pluginInfo{}
+	for _, p := range response.Plugins {
+		if !p.Deleted {
+			graph[p.ShortName] = p
+		}
+	}
+	return graph, nil
+}
+
+// dependents returns the installed plugins that depend on name, sorted
+func dependents(graph map[string]*pluginInfo, name string, includeOptional bool) []pluginDependency {
+	var result []pluginDependency
+	for _, p := range graph {
+		for _, d := range p.Dependencies {
+			if d.ShortName == name && (includeOptional || !d.Optional) {
+				result = append(result, pluginDependency{ShortName: p.ShortName, Version: p.Version, Optional: d.Optional})
+			}
+		}
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].ShortName < result[b].ShortName })
+	return result
+}
+
+// printPluginTree will print a dependency tree, edges returns the
+// children of a plugin. Plugins already in the current path are not
+// expanded again to avoid cycles
+func printPluginTree(graph map[string]*pluginInfo, name string, prefix string, path map[string]bool, edges func(string) []pluginDependency) {
+	children := edges(name)
+	for i, d := range children {
+		branch, next := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		details := d.Version
+		if p, ok := graph[d.ShortName]; !ok {
+			details += " ❌ not installed"
+		} else if p.Version != d.Version {
+			details = fmt.Sprintf("%s (installed %s)", d.Version, p.Version)
+		}
+		if d.Optional {
+			details += " (optional)"
+		}
+		fmt.Printf("%s%s%s %s\n", prefix, branch, d.ShortName, details)
+
+		if path[d.ShortName] {
+			continue
+		}
+		path[d.ShortName] = true
+		printPluginTree(graph, d.ShortName, prefix+next, path, edges)
+		delete(path, d.ShortName)
+	}
+}
+
+// ShowPluginDeps show the dependencies of a plugin and the plugins that
+// depend on it
+//
+// Args:
+//	name - plugin short name
+//
+// Returns
+//	nil or error
+func (j *Jenkins) ShowPluginDeps(name string) error {
+	graph, err := j.getPluginGraph()
+	if err != nil {
+		return err
+	}
+	plugin, ok := graph[name]
+	if !ok {
+		return fmt.Errorf("❌ plugin %s is not installed", name)
+	}
+
+	fmt.Printf("Dependencies of %s %s\n", plugin.ShortName, plugin.Version)
+	if len(plugin.Dependencies) == 0 {
+		fmt.Printf("    none\n")
+	}
+	printPluginTree(graph, name, "", map[string]bool{name: true}, func(n string) []pluginDependency {
+		if p, ok := graph[n]; ok {
+			deps := append([]pluginDependency{}, p.Dependencies...)
+			sort.Slice(deps, func(a, b int) bool { return deps[a].ShortName < deps[b].ShortName })
+			return deps
+		}
+		return nil
+	})
+
+	fmt.Printf("\nRequired by\n")
+	if len(dependents(graph, name, true)) == 0 {
+		fmt.Printf("    none\n")
+	}
+	printPluginTree(graph, name, "", map[string]bool{name: true}, func(n string) []pluginDependency {
+		return dependents(graph, n, true)
+	})
+	return nil
+}
+
+// UninstallPlugin will uninstall a plugin, it refuses when other installed
+// plugins depend on it unless force is true
+//
+// Args:
+//	name - plugin short name
+//	force - uninstall even when other plugins depend on it
+//
+// Returns
+//	nil or error
+func (j *Jenkins) UninstallPlugin(name string, force bool) error {
+	graph, err := j.getPluginGraph()
+	if err != nil {
+		return err
+	}
+	if _, ok := graph[name]; !ok {
+		return fmt.Errorf("cannot find plugin %s in the server", name)
+	}
+
+	required := dependents(graph, name, false)
+	if len(required) > 0 {
+		var names []string
+		for _, d := range required {
+			names = append(names, d.ShortName)
+		}
+		if !force {
+			return fmt.Errorf("plugin %s is required by: %s (use --force to uninstall anyway)", name, strings.Join(names, ", "))
+		}
+		fmt.Printf("⚠️  plugin %s is required by: %s\n", name, strings.Join(names, ", "))
+	}
+
+	return j.postForm("/pluginManager/plugin/"+url.PathEscape(name)+"/doUninstall", nil)
+}
+
+// updateCenterJobs are the install jobs of the update center
+type updateCenterJobs struct {
+	Jobs []struct {
+		ID           int    `json:"id"`
+		Type         string `json:"type"`
+		Name         string `json:"name"`
+		ErrorMessage string `json:"errorMessage"`
+		Status       struct {
+			Type    string `json:"type"`
+			Success bool   `json:"success"`
+		} `json:"status"`
+	} `json:"jobs"`
+}
+
+// pluginUploadTimeout is how long UploadPlugin waits for the install job
+const pluginUploadTimeout = 5 * time.Minute
+
+// IsPluginArchive returns true for .hpi and .jpi files
+func IsPluginArchive(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".hpi" || ext == ".jpi"
+}
+
+// pluginShortName will read the Short-Name from the plugin manifest,
+// the file name is used when the manifest cannot be read
+//
+// Args:
+//	path - path to the .hpi/.jpi file
+//
+// Returns
+//	plugin short name
+func pluginShortName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return name
+	}
+	defer archive.Close()
+
+	for _, f := range archive.File {
+		if f.Name != "META-INF/MANIFEST.MF" {
+			continue
+		}
+		manifest, err := f.Open()
+		if err != nil {
+			return name
+		}
+		defer manifest.Close()
+
+		scanner := bufio.NewScanner(manifest)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if strings.HasPrefix(line, "Short-Name:") {
+				return strings.TrimSpace(strings.TrimPrefix(line, "Short-Name:"))
+			}
+		}
+	}
+	return name
+}
+
+// UploadPlugin will upload a .hpi/.jpi file to pluginManager/uploadPlugin
+// and wait for the update center install job to finish
+//
+// Args:
+//	path - path to the .hpi/.jpi file
+//
+// Returns
+//	nil or error
+func (j *Jenkins) UploadPlugin(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	name := pluginShortName(path)
+
+	// ignore install jobs created before the upload
+	jobs := updateCenterJobs{}
+	err := j.getJSON("/updateCenter", &jobs, map[string]string{"depth": "1"})
+	if err != nil {
+		return err
+	}
+	lastID := 0
+	for _, job := range jobs.Jobs {
+		if job.ID > lastID {
+			lastID = job.ID
+		}
+	}
+
+	fmt.Printf("⏳ Uploading %s...\n", filepath.Base(path))
+	resp, err := j.Instance.Requester.PostFiles(j.Context, "/pluginManager/uploadPlugin", strings.NewReader("{}"), nil, nil, []string{path})
+	if err != nil {
+		return err
+	}
+	err = checkStatus(resp, "/pluginManager/uploadPlugin")
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(pluginUploadTimeout)
+	status := ""
+	for time.Now().Before(deadline) {
+		jobs = updateCenterJobs{}
+		err = j.getJSON("/updateCenter", &jobs, map[string]string{"depth": "1"})
+		if err != nil {
+			return err
+		}
+
+		// the most recent install job of the plugin has the highest id
+		found := false
+		for i := len(jobs.Jobs) - 1; i >= 0; i-- {
+			job := jobs.Jobs[i]
+			if job.Name != name || job.ID <= lastID {
+				continue
+			}
+			found = true
+			if job.Status.Type != status {
+				status = job.Status.Type
+				fmt.Printf("    %s: %s\n", name, status)
+			}
+			switch {
+			case job.Status.Success:
+				fmt.Printf("✅ Plugin %s installed\n", name)
+				if strings.Contains(status, "Restart") {
+					fmt.Printf("⚠️  Jenkins must be restarted to load the plugin\n")
+				}
+				return nil
+			case status == "Failure":
+				return fmt.Errorf("install of plugin %s failed: %s", name, job.ErrorMessage)
+			}
+			break
+		}
+		if !found && status == "" {
+			status = "Pending"
+			fmt.Printf("    %s: waiting for the install job\n", name)
+		}
+
+		select {
+		case <-j.Context.Done():
+			return j.Context.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+	return fmt.Errorf("timeout waiting for plugin %s to be installed", name)
+}
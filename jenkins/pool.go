@@ -0,0 +1,46 @@
+package jenkins
+
+import (
+	"sync"
+)
+
+// DefaultWorkers is the number of concurrent requests used when
+// polling many objects from the server
+const DefaultWorkers = 8
+
+// runParallel will call fn for every index in [0, count) using at most
+// workers goroutines at the same time
+//
+// Args:
+//	count - number of items
+//	workers - max number of concurrent calls
+//	fn - function called with the item index
+//
+// Returns
+//
+func runParallel(count int, workers int, fn func(i int)) {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	if workers > count {
+		workers = count
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,168 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Size of the fake controller used by the benchmarks
+const (
+	fakeJobs  = 3000
+	fakeNodes = 50
+)
+
+// newFakeServer returns a server with the api/json endpoints of a
+// controller with the given number of jobs and nodes
+func newFakeServer(jobs int, nodes int) *httptest.Server {
+	jobList := make([]map[string]interface{}, jobs)
+	jobByName := map[string]map[string]interface{}{}
+	for i := range jobList {
+		name := fmt.Sprintf("job-%d", i)
+		jobList[i] = map[string]interface{}{
+			"_class":      "hudson.model.FreeStyleProject",
+			"name":        name,
+			"fullName":    name,
+			"url":         "http://jenkins/job/" + name + "/",
+			"color":       "blue",
+			"description": "fake job",
+		}
+		jobByName[name] = jobList[i]
+	}
+	nodeList := make([]map[string]interface{}, nodes)
+	nodeByName := map[string]map[string]interface{}{}
+	for i := range nodeList {
+		name := fmt.Sprintf("node-%d", i)
+		nodeList[i] = map[string]interface{}{
+			"displayName":  name,
+			"offline":      i%10 == 0,
+			"idle":         true,
+			"numExecutors": 2,
+		}
+		nodeByName[name] = nodeList[i]
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/api/json")
+		var response interface{}
+		switch {
+		case path == "":
+			response = map[string]interface{}{"jobs": jobList}
+		case path == "/computer":
+			response = map[string]interface{}{"computer": nodeList}
+		case path == "/overallLoad":
+			response = map[string]interface{}{"queueLength": map[string]interface{}{}}
+		case path == "/queue":
+			response = map[string]interface{}{"items": []interface{}{}}
+		case strings.HasPrefix(path, "/job/") && jobByName[strings.TrimPrefix(path, "/job/")] != nil:
+			response = jobByName[strings.TrimPrefix(path, "/job/")]
+		case strings.HasPrefix(path, "/computer/") && nodeByName[strings.TrimPrefix(path, "/computer/")] != nil:
+			response = nodeByName[strings.TrimPrefix(path, "/computer/")]
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("X-Jenkins", "2.300")
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+// benchmarkRequests runs fn b.N times against the fake server, checks the
+// number of requests of each call and reports it as requests/op
+func benchmarkRequests(b *testing.B, want int64, fn func(j *Jenkins) error) {
+	server := newFakeServer(fakeJobs, fakeNodes)
+	defer server.Close()
+
+	j := &Jenkins{}
+	if err := j.Init(Config{Server: server.URL, JenkinsUser: "user", Token: "token"}); err != nil {
+		b.Fatal(err)
+	}
+
+	// the Show functions print to stdout
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = stdout
+		devNull.Close()
+	}()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		start := j.RequestCount()
+		if err := fn(j); err != nil {
+			b.Fatal(err)
+		}
+		if got := j.RequestCount() - start; got != want {
+			b.Fatalf("sent %d requests, want %d", got, want)
+		}
+	}
+	b.ReportMetric(float64(want), "requests/op")
+}
+
+func BenchmarkShowAllJobs(b *testing.B) {
+	benchmarkRequests(b, 1, func(j *Jenkins) error {
+		return j.ShowAllJobs()
+	})
+}
+
+// BenchmarkGetAllJobs is the gojenkins call used by ShowAllJobs before,
+// one request per job
+func BenchmarkGetAllJobs(b *testing.B) {
+	benchmarkRequests(b, 1+fakeJobs, func(j *Jenkins) error {
+		_, err := j.Instance.GetAllJobs(j.Context)
+		return err
+	})
+}
+
+func BenchmarkShowNodes(b *testing.B) {
+	benchmarkRequests(b, 1, func(j *Jenkins) error {
+		_, err := j.ShowNodes("online")
+		return err
+	})
+}
+
+// BenchmarkGetAllNodes is the gojenkins call used by ShowNodes before,
+// the node list and a poll per node
+func BenchmarkGetAllNodes(b *testing.B) {
+	benchmarkRequests(b, 1+fakeNodes, func(j *Jenkins) error {
+		nodes, err := j.Instance.GetAllNodes(j.Context)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			if _, err = node.Poll(j.Context); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func BenchmarkShowNodeExecutors(b *testing.B) {
+	// the node list and the queue length
+	benchmarkRequests(b, 2, func(j *Jenkins) error {
+		return j.ShowNodeExecutors()
+	})
+}
+
+func BenchmarkShowBuildQueue(b *testing.B) {
+	benchmarkRequests(b, 1, func(j *Jenkins) error {
+		return j.ShowBuildQueue()
+	})
+}
+
+// BenchmarkGetQueue is the gojenkins call used by ShowBuildQueue before
+func BenchmarkGetQueue(b *testing.B) {
+	benchmarkRequests(b, 1, func(j *Jenkins) error {
+		_, err := j.Instance.GetQueue(j.Context)
+		return err
+	})
+}
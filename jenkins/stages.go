@@ -0,0 +1,249 @@
+package jenkins
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// htmlTag matches the console annotations in the wfapi logs
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// StageInfo is a pipeline stage from wfapi/describe
+type StageInfo struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	Status              string `json:"status"`
+	StartTimeMillis     int64  `json:"startTimeMillis"`
+	DurationMillis      int64  `json:"durationMillis"`
+	PauseDurationMillis int64  `json:"pauseDurationMillis"`
+}
+
+// RunInfo is a pipeline build from wfapi/describe
+type RunInfo struct {
+	ID                  string      `json:"id"`
+	Name                string      `json:"name"`
+	Status              string      `json:"status"`
+	StartTimeMillis     int64       `json:"startTimeMillis"`
+	DurationMillis      int64       `json:"durationMillis"`
+	PauseDurationMillis int64       `json:"pauseDurationMillis"`
+	Stages              []StageInfo `json:"stages"`
+}
+
+// stageNode is a stage with its steps from execution/node/ID/wfapi/describe
+type stageNode struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	StageFlowNodes []struct {
+		ID                   string `json:"id"`
+		Name                 string `json:"name"`
+		Status               string `json:"status"`
+		ParameterDescription string `json:"parameterDescription"`
+	} `json:"stageFlowNodes"`
+}
+
+// nodeLog is the log of a step from execution/node/ID/wfapi/log
+type nodeLog struct {
+	NodeID     string `json:"nodeId"`
+	NodeStatus string `json:"nodeStatus"`
+	Length     int64  `json:"length"`
+	HasMore    bool   `json:"hasMore"`
+	Text       string `json:"text"`
+	ConsoleURL string `json:"consoleUrl"`
+}
+
+// statusIcons are the icons of the wfapi status and build results
+var statusIcons = map[string]string{
+	"SUCCESS":              "✅",
+	"FAILED":               "❌",
+	"FAILURE":              "❌",
+	"NOT_BUILT":            "⏭️ ",
+	"UNSTABLE":             "⚠️ ",
+	"ABORTED":              "🚫",
+	"IN_PROGRESS":          "⏳",
+	"PAUSED_PENDING_INPUT": "⏸️ ",
+	"NOT_EXECUTED":         "⏭️ ",
+}
+
+// formatMillis returns a readable duration, i.e: 1m5s
+func formatMillis(ms int64) string {
+	d := time.Duration(ms) * time.Millisecond
+	if d < time.Second {
+		return d.String()
+	}
+	return d.Round(time.Second).String()
+}
+
+// GetRunStages will get the stages of a pipeline build
+//
+// Args:
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//
+// Returns
+//	pipeline run, nil or error
+func (j *Jenkins) GetRunStages(jobName string, build string) (*RunInfo, error) {
+	run := &RunInfo{}
+	endpoint := buildBase(jobName, build) + "/wfapi/describe"
+	resp, err := j.Instance.Requester.Get(j.Context, endpoint, run, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == 404 {
+		return nil, fmt.Errorf("build %s of %s not found or it is not a pipeline", build, jobName)
+	}
+	if err = checkStatus(resp, endpoint); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// ShowStages will show the stages of a pipeline build with status,
+// duration and pause time
+//
+// Args:
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//
+// Returns
+//	nil or error
+func (j *Jenkins) ShowStages(jobName string, build string) error {
+	run, err := j.GetRunStages(jobName, build)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s %s %s %s (duration: %s, paused: %s)\n\n",
+		statusIcons[run.Status], jobName, run.Name, run.Status,
+		formatMillis(run.DurationMillis), formatMillis(run.PauseDurationMillis))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "STAGE\tSTATUS\tDURATION\tPAUSED\n")
+	for _, s := range run.Stages {
+		fmt.Fprintf(w, "%s\t%s %s\t%s\t%s\n",
+			s.Name, statusIcons[s.Status], s.Status,
+			formatMillis(s.DurationMillis), formatMillis(s.PauseDurationMillis))
+	}
+	w.Flush()
+	if len(run.Stages) == 0 {
+		fmt.Printf("No stages found\n")
+	}
+	return nil
+}
+
+// WriteStageLog will write the log of the steps of a pipeline stage
+//
+// Args:
+//	out - where the log is written
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//	stageName - stage name
+//
+// Returns
+//	nil or error
+func (j *Jenkins) WriteStageLog(out io.Writer, jobName string, build string, stageName string) error {
+	run, err := j.GetRunStages(jobName, build)
+	if err != nil {
+		return err
+	}
+
+	var stage *StageInfo
+	var names []string
+	for i := range run.Stages {
+		names = append(names, run.Stages[i].Name)
+		if run.Stages[i].Name == stageName {
+			stage = &run.Stages[i]
+		}
+	}
+	if stage == nil {
+		return fmt.Errorf("stage %s not found, stages: %s", stageName, strings.Join(names, ", "))
+	}
+
+	base := buildBase(jobName, build) + "/execution/node/"
+	node := stageNode{}
+	endpoint := base + stage.ID + "/wfapi/describe"
+	resp, err := j.Instance.Requester.Get(j.Context, endpoint, &node, nil)
+	if err != nil {
+		return err
+	}
+	if err = checkStatus(resp, endpoint); err != nil {
+		return err
+	}
+
+	for _, step := range node.StageFlowNodes {
+		log := nodeLog{}
+		endpoint = base + step.ID + "/wfapi/log"
+		resp, err = j.Instance.Requester.Get(j.Context, endpoint, &log, nil)
+		if err != nil {
+			return err
+		}
+		if err = checkStatus(resp, endpoint); err != nil {
+			return err
+		}
+
+		title := step.Name
+		if step.ParameterDescription != "" {
+			title += ": " + step.ParameterDescription
+		}
+		fmt.Fprintf(out, "%s [%s] %s\n", statusIcons[step.Status], stage.Name, title)
+		if log.HasMore {
+			// wfapi/log only has the end of long logs, the full text is
+			// read from the log action of the node with start=
+			size, _, err := j.readProgressiveLog(out, base+step.ID+"/log/logText/progressiveText", 0)
+			if err != nil {
+				return err
+			}
+			if size > 0 {
+				continue
+			}
+		}
+		fmt.Fprint(out, html.UnescapeString(htmlTag.ReplaceAllString(log.Text, "")))
+		if log.HasMore {
+			fmt.Fprintf(out, "... log truncated, full log: %s\n", j.serverURL(log.ConsoleURL))
+		}
+	}
+	return nil
+}
+
+// serverURL returns the absolute URL of a path returned by the server, the
+// path already has the context path of the server, i.e: /jenkins
+func (j *Jenkins) serverURL(path string) string {
+	server, err := url.Parse(j.Server)
+	if err != nil {
+		return path
+	}
+	ref, err := url.Parse(path)
+	if err != nil {
+		return path
+	}
+	return server.ResolveReference(ref).String()
+}
+
+// WriteBuildLog will write the console log of a build
+//
+// Args:
+//	out - where the log is written
+//	jobName - job full name
+//	build - build number or permalink, i.e: lastBuild
+//
+// Returns
+//	nil or error
+func (j *Jenkins) WriteBuildLog(out io.Writer, jobName string, build string) error {
+	var text string
+	endpoint := buildBase(jobName, build) + "/consoleText"
+	resp, err := j.Instance.Requester.GetXML(j.Context, endpoint, &text, nil)
+	if err != nil {
+		return err
+	}
+	if err = checkStatus(resp, endpoint); err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(out, text)
+	return err
+}
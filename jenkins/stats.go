@@ -0,0 +1,326 @@
+package jenkins
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// sparkBlocks are the levels of the duration sparkline
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// resultMarks are the marks of the results strip
+var resultMarks = map[string]string{
+	"SUCCESS":  "+",
+	"FAILURE":  "x",
+	"UNSTABLE": "!",
+	"ABORTED":  "-",
+}
+
+// BuildRecord is a build used by the job statistics
+type BuildRecord struct {
+	Number    int64  `json:"number"`
+	Result    string `json:"result"`
+	Building  bool   `json:"building"`
+	Timestamp int64  `json:"timestamp"`
+	Duration  int64  `json:"duration"`
+	// QueueMillis is -1 when the metrics plugin is not installed
+	QueueMillis int64 `json:"queueMillis"`
+}
+
+// broken returns true for the results that start or continue a failure
+// streak, aborted builds are ignored
+func (b *BuildRecord) broken() bool {
+	return b.Result == "FAILURE" || b.Result == "UNSTABLE"
+}
+
+// DurationStats are duration statistics in milliseconds
+type DurationStats struct {
+	Mean int64 `json:"mean"`
+	P50  int64 `json:"p50"`
+	P95  int64 `json:"p95"`
+	Max  int64 `json:"max"`
+}
+
+// JobStats are the statistics of the build history of a job
+type JobStats struct {
+	Job                string         `json:"job"`
+	Builds             int            `json:"builds"`
+	Completed          int            `json:"completed"`
+	Results            map[string]int `json:"results"`
+	SuccessRate        float64        `json:"successRate"`
+	Duration           DurationStats  `json:"duration"`
+	QueueWait          *DurationStats `json:"queueWait"`
+	LongestFailStreak  int            `json:"longestFailStreak"`
+	CurrentFailStreak  int            `json:"currentFailStreak"`
+	Recoveries         int            `json:"recoveries"`
+	MeanTimeToRecovery int64          `json:"meanTimeToRecoveryMillis"`
+	MaxTimeToRecovery  int64          `json:"maxTimeToRecoveryMillis"`
+	DurationSparkline  string         `json:"durationSparkline"`
+	ResultStrip        string         `json:"resultStrip"`
+	History            []BuildRecord  `json:"history"`
+}
+
+// buildHistoryFields are the fields of each build in the history query,
+// queuingDurationMillis is added by the metrics plugin
+const buildHistoryFields = "number,result,building,timestamp,duration,actions[queuingDurationMillis]"
+
+// GetBuildHistory will get the last builds of a job with a single request
+//
+// Args:
+//	jobName - job full name
+//	count - number of builds
+//
+// Returns
+//	builds, oldest first, nil or error
+func (j *Jenkins) GetBuildHistory(jobName string, count int) ([]BuildRecord, error) {
+	response := struct {
+		AllBuilds []struct {
+			BuildRecord
+			Actions []struct {
+				QueuingDurationMillis *int64 `json:"queuingDurationMillis"`
+			} `json:"actions"`
+		} `json:"allBuilds"`
+	}{}
+	tree := fmt.Sprintf("allBuilds[%s]{0,%d}", buildHistoryFields, count)
+	err := j.getJSON(jobBase(jobName), &response, map[string]string{"tree": tree})
+	if err != nil {
+		return nil, err
+	}
+
+	builds := make([]BuildRecord, 0, len(response.AllBuilds))
+	for i := len(response.AllBuilds) - 1; i >= 0; i-- {
+		b := response.AllBuilds[i].BuildRecord
+		b.QueueMillis = -1
+		for _, a := range response.AllBuilds[i].Actions {
+			if a.QueuingDurationMillis != nil {
+				b.QueueMillis = *a.QueuingDurationMillis
+			}
+		}
+		builds = append(builds, b)
+	}
+	return builds, nil
+}
+
+// durationStats computes mean, p50, p95 and max using the nearest rank
+func durationStats(values []int64) DurationStats {
+	if len(values) == 0 {
+		return DurationStats{}
+	}
+	sorted := append([]int64{}, values...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a] < sorted[b] })
+	var sum int64
+	for _, v := range sorted {
+		sum += v
+	}
+	rank := func(p float64) int64 {
+		i := int(p*float64(len(sorted))+0.999999) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	return DurationStats{
+		Mean: sum / int64(len(sorted)),
+		P50:  rank(0.50),
+		P95:  rank(0.95),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// sparkline draws the values with block characters
+func sparkline(values []int64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	var line strings.Builder
+	for _, v := range values {
+		level := 0
+		if max > min {
+			level = int((v - min) * int64(len(sparkBlocks)-1) / (max - min))
+		}
+		line.WriteRune(sparkBlocks[level])
+	}
+	return line.String()
+}
+
+// ComputeJobStats will compute the statistics of a build history
+//
+// Args:
+//	jobName - job full name
+//	builds - builds, oldest first
+//
+// Returns
+//	statistics
+func ComputeJobStats(jobName string, builds []BuildRecord) *JobStats {
+	stats := &JobStats{Job: jobName, Builds: len(builds), Results: map[string]int{}, History: builds}
+
+	var durations, queue []int64
+	var recoveries []int64
+	var strip strings.Builder
+	streak, streakStart := 0, int64(0)
+	for _, b := range builds {
+		if b.Building {
+			strip.WriteString(".")
+			continue
+		}
+		stats.Completed++
+		stats.Results[b.Result]++
+		durations = append(durations, b.Duration)
+		if b.QueueMillis >= 0 {
+			queue = append(queue, b.QueueMillis)
+		}
+		mark, ok := resultMarks[b.Result]
+		if !ok {
+			mark = "?"
+		}
+		strip.WriteString(mark)
+
+		switch {
+		case b.broken():
+			if streak == 0 {
+				streakStart = b.Timestamp
+			}
+			streak++
+			if streak > stats.LongestFailStreak {
+				stats.LongestFailStreak = streak
+			}
+		case b.Result == "SUCCESS":
+			if streak > 0 {
+				recoveries = append(recoveries, b.Timestamp+b.Duration-streakStart)
+			}
+			streak = 0
+		}
+	}
+	stats.CurrentFailStreak = streak
+
+	if stats.Completed > 0 {
+		stats.SuccessRate = float64(stats.Results["SUCCESS"]) / float64(stats.Completed) * 100
+	}
+	stats.Duration = durationStats(durations)
+	if len(queue) > 0 {
+		q := durationStats(queue)
+		stats.QueueWait = &q
+	}
+	stats.Recoveries = len(recoveries)
+	if len(recoveries) > 0 {
+		r := durationStats(recoveries)
+		stats.MeanTimeToRecovery = r.Mean
+		stats.MaxTimeToRecovery = r.Max
+	}
+	stats.DurationSparkline = sparkline(durations)
+	stats.ResultStrip = strip.String()
+	return stats
+}
+
+// AnalyzeJob will collect the build history of a job and compute its
+// statistics
+//
+// Args:
+//	jobName - job full name
+//	count - number of builds
+//
+// Returns
+//	statistics, nil or error
+func (j *Jenkins) AnalyzeJob(jobName string, count int) (*JobStats, error) {
+	builds, err := j.GetBuildHistory(jobName, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(builds) == 0 {
+		return nil, fmt.Errorf("job %s has no builds", jobName)
+	}
+	return ComputeJobStats(jobName, builds), nil
+}
+
+// WriteJobStats will write the statistics as table, json or csv, the
+// csv has a row per build for dashboards
+//
+// Args:
+//	out - where the report is written
+//	stats - job statistics
+//	format - table, json or csv
+//
+// Returns
+//	nil or error
+func WriteJobStats(out io.Writer, stats *JobStats, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	case "csv":
+		w := csv.NewWriter(out)
+		w.Write([]string{"job", "number", "result", "timestamp", "durationMillis", "queueMillis"})
+		for _, b := range stats.History {
+			result := b.Result
+			if b.Building {
+				result = "IN_PROGRESS"
+			}
+			queue := ""
+			if b.QueueMillis >= 0 {
+				queue = strconv.FormatInt(b.QueueMillis, 10)
+			}
+			w.Write([]string{
+				stats.Job,
+				strconv.FormatInt(b.Number, 10),
+				result,
+				time.Unix(0, b.Timestamp*int64(time.Millisecond)).UTC().Format(time.RFC3339),
+				strconv.FormatInt(b.Duration, 10),
+				queue,
+			})
+		}
+		w.Flush()
+		return w.Error()
+	case "table":
+		results := make([]string, 0, len(stats.Results))
+		for r, n := range stats.Results {
+			results = append(results, fmt.Sprintf("%s=%d", r, n))
+		}
+		sort.Strings(results)
+
+		queue := "n/a (metrics plugin not installed)"
+		if stats.QueueWait != nil {
+			queue = fmt.Sprintf("mean %s, p50 %s, p95 %s", formatMillis(stats.QueueWait.Mean),
+				formatMillis(stats.QueueWait.P50), formatMillis(stats.QueueWait.P95))
+		}
+		recovery := "-"
+		if stats.Recoveries > 0 {
+			recovery = fmt.Sprintf("mean %s, max %s (%d recoveries)", formatMillis(stats.MeanTimeToRecovery),
+				formatMillis(stats.MaxTimeToRecovery), stats.Recoveries)
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Job\t%s\n", stats.Job)
+		fmt.Fprintf(w, "Builds\t%d (%d completed)\n", stats.Builds, stats.Completed)
+		fmt.Fprintf(w, "Results\t%s\n", strings.Join(results, " "))
+		fmt.Fprintf(w, "Success rate\t%.1f%%\n", stats.SuccessRate)
+		fmt.Fprintf(w, "Duration\tmean %s, p50 %s, p95 %s, max %s\n", formatMillis(stats.Duration.Mean),
+			formatMillis(stats.Duration.P50), formatMillis(stats.Duration.P95), formatMillis(stats.Duration.Max))
+		fmt.Fprintf(w, "Queue wait\t%s\n", queue)
+		fmt.Fprintf(w, "Longest failure streak\t%d\n", stats.LongestFailStreak)
+		fmt.Fprintf(w, "Current failure streak\t%d\n", stats.CurrentFailStreak)
+		fmt.Fprintf(w, "Time to recovery\t%s\n", recovery)
+		fmt.Fprintf(w, "Duration trend\t%s\n", stats.DurationSparkline)
+		fmt.Fprintf(w, "Results trend\t%s\n", stats.ResultStrip)
+		w.Flush()
+		fmt.Fprintf(out, "\nOldest build on the left, results: + success, x failure, ! unstable, - aborted, . running\n")
+		return nil
+	}
+	return fmt.Errorf("unknown format: %s (use table, csv or json)", format)
+}
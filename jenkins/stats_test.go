@@ -0,0 +1,110 @@
+package jenkins
+
+import (
+	"reflect"
+	"testing"
+)
+
+// completedBuild returns a completed build without queue time
+func completedBuild(number int64, result string, timestamp int64, duration int64) BuildRecord {
+	return BuildRecord{Number: number, Result: result, Timestamp: timestamp, Duration: duration, QueueMillis: -1}
+}
+
+func TestComputeJobStats(t *testing.T) {
+	// durations 1..20 in a mixed order, nearest rank p95 is the 19th value
+	var ranked []BuildRecord
+	for i, d := range []int64{20, 3, 17, 1, 9, 12, 5, 19, 7, 14, 2, 16, 10, 4, 18, 8, 13, 6, 15, 11} {
+		ranked = append(ranked, completedBuild(int64(i+1), "SUCCESS", int64(i)*100, d))
+	}
+
+	running := []BuildRecord{
+		{Number: 1, Result: "FAILURE", Timestamp: 0, Duration: 10, QueueMillis: 5},
+		{Number: 2, Result: "FAILURE", Timestamp: 100, Duration: 30, QueueMillis: 7},
+		{Number: 3, Building: true, Timestamp: 200, QueueMillis: -1},
+	}
+
+	tests := []struct {
+		name        string
+		builds      []BuildRecord
+		completed   int
+		results     map[string]int
+		duration    DurationStats
+		queueWait   *DurationStats
+		longest     int
+		current     int
+		recoveries  int
+		meanRecover int64
+		maxRecover  int64
+		strip       string
+	}{
+		{
+			name:      "nearest rank",
+			builds:    ranked,
+			completed: 20,
+			results:   map[string]int{"SUCCESS": 20},
+			duration:  DurationStats{Mean: 10, P50: 10, P95: 19, Max: 20},
+			strip:     "++++++++++++++++++++",
+		},
+		{
+			// the aborted build does not end the first streak, recoveries
+			// are 400+50-100 and 600+50-500
+			name: "streak spanning aborted builds",
+			builds: []BuildRecord{
+				completedBuild(1, "SUCCESS", 0, 10),
+				completedBuild(2, "FAILURE", 100, 10),
+				completedBuild(3, "ABORTED", 200, 10),
+				completedBuild(4, "FAILURE", 300, 10),
+				completedBuild(5, "SUCCESS", 400, 50),
+				completedBuild(6, "UNSTABLE", 500, 10),
+				completedBuild(7, "SUCCESS", 600, 50),
+			},
+			completed:   7,
+			results:     map[string]int{"SUCCESS": 3, "FAILURE": 2, "ABORTED": 1, "UNSTABLE": 1},
+			duration:    DurationStats{Mean: 21, P50: 10, P95: 50, Max: 50},
+			longest:     2,
+			recoveries:  2,
+			meanRecover: 250,
+			maxRecover:  350,
+			strip:       "+x-x+!+",
+		},
+		{
+			name:      "running last build",
+			builds:    running,
+			completed: 2,
+			results:   map[string]int{"FAILURE": 2},
+			duration:  DurationStats{Mean: 20, P50: 10, P95: 30, Max: 30},
+			queueWait: &DurationStats{Mean: 6, P50: 5, P95: 7, Max: 7},
+			longest:   2,
+			current:   2,
+			strip:     "xx.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := ComputeJobStats("app", tt.builds)
+			if stats.Builds != len(tt.builds) || stats.Completed != tt.completed {
+				t.Errorf("builds %d completed %d, want %d and %d", stats.Builds, stats.Completed, len(tt.builds), tt.completed)
+			}
+			if !reflect.DeepEqual(stats.Results, tt.results) {
+				t.Errorf("results %v, want %v", stats.Results, tt.results)
+			}
+			if stats.Duration != tt.duration {
+				t.Errorf("duration %+v, want %+v", stats.Duration, tt.duration)
+			}
+			if !reflect.DeepEqual(stats.QueueWait, tt.queueWait) {
+				t.Errorf("queue wait %+v, want %+v", stats.QueueWait, tt.queueWait)
+			}
+			if stats.LongestFailStreak != tt.longest || stats.CurrentFailStreak != tt.current {
+				t.Errorf("streaks longest %d current %d, want %d and %d", stats.LongestFailStreak, stats.CurrentFailStreak, tt.longest, tt.current)
+			}
+			if stats.Recoveries != tt.recoveries || stats.MeanTimeToRecovery != tt.meanRecover || stats.MaxTimeToRecovery != tt.maxRecover {
+				t.Errorf("recoveries %d mean %d max %d, want %d, %d and %d", stats.Recoveries, stats.MeanTimeToRecovery,
+					stats.MaxTimeToRecovery, tt.recoveries, tt.meanRecover, tt.maxRecover)
+			}
+			if stats.ResultStrip != tt.strip {
+				t.Errorf("result strip %q, want %q", stats.ResultStrip, tt.strip)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package jenkins
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// IsTemplate returns true for files rendered with text/template by
+// default, i.e: job.xml.tmpl
+func IsTemplate(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".tmpl" || ext == ".tpl"
+}
+
+// templateFuncs are the functions available in the job templates
+var templateFuncs = template.FuncMap{
+	// xml escapes a value to be used inside a XML element or attribute
+	"xml": func(value interface{}) (string, error) {
+		if value == nil {
+			return "", nil
+		}
+		var b bytes.Buffer
+		err := xml.EscapeText(&b, []byte(fmt.Sprint(value)))
+		return b.String(), err
+	},
+	"default": func(def interface{}, value interface{}) interface{} {
+		if value == nil || value == "" {
+			return def
+		}
+		return value
+	},
+	"required": func(name string, value interface{}) (interface{}, error) {
+		if value == nil || value == "" {
+			return nil, fmt.Errorf("value %s is required", name)
+		}
+		return value, nil
+	},
+	"join": func(sep string, values []interface{}) string {
+		s := make([]string, len(values))
+		for i, v := range values {
+			s[i] = fmt.Sprint(v)
+		}
+		return strings.Join(s, sep)
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// normalize converts the maps decoded by yaml into map[string]interface{}
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalize(item)
+		}
+		return m
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	}
+	return value
+}
+
+// merge copies src into dst, nested maps are merged
+func merge(dst map[string]interface{}, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcOK := value.(map[string]interface{})
+		dstMap, dstOK := dst[key].(map[string]interface{})
+		if srcOK && dstOK {
+			merge(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// LoadValues will load the template values from yaml files and
+// key=value pairs, later values override the previous ones. Nested keys
+// are separated by dot, i.e: scm.branch=main
+//
+// Args:
+//	files - yaml files
+//	sets - key=value pairs
+//
+// Returns
+//	values, nil or error
+func LoadValues(files []string, sets []string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var fileValues map[interface{}]interface{}
+		if err = yaml.Unmarshal(data, &fileValues); err != nil {
+			return nil, fmt.Errorf("%s: %s", f, err)
+		}
+		if fileValues != nil {
+			merge(values, normalize(fileValues).(map[string]interface{}))
+		}
+	}
+
+	for _, s := range sets {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid value: %s (use key=value)", s)
+		}
+		keys := strings.Split(kv[0], ".")
+		current := values
+		for _, key := range keys[:len(keys)-1] {
+			next, ok := current[key].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				current[key] = next
+			}
+			current = next
+		}
+		current[keys[len(keys)-1]] = kv[1]
+	}
+	return values, nil
+}
+
+// RenderTemplate will render a XML template with text/template and
+// check that the result is well-formed XML
+//
+// Args:
+//	path - path to the template
+//	values - template values, available as .key, a missing key is an
+//	error, optional values are read with index
+//
+// Returns
+//	rendered XML, nil or error
+func RenderTemplate(path string, values map[string]interface{}) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	// a missing value must not be rendered as <no value>, optional values
+	// are read with index, i.e: {{ index . "description" | default "" }}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err = tmpl.Execute(&out, values); err != nil {
+		return "", err
+	}
+
+	if err = CheckXML(out.String()); err != nil {
+		return "", fmt.Errorf("rendered %s is not well-formed XML: %s", path, err)
+	}
+	return out.String(), nil
+}
+
+// CheckXML will return an error when the document is not well-formed XML
+func CheckXML(document string) error {
+	decoder := xml.NewDecoder(strings.NewReader(stripXMLDeclaration(document)))
+	root := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			root = true
+		}
+	}
+	if !root {
+		return fmt.Errorf("missing root element")
+	}
+	return nil
+}
+
+// LoadJobConfig will read a job config.xml, templates are rendered when
+// the file is a template or values are given and YAML job specs are
+// converted to XML
+//
+// Args:
+//	path - path to the XML, template or YAML job spec
+//	files - yaml values files
+//	sets - key=value pairs
+//
+// Returns
+//	job config.xml, nil or error
+func LoadJobConfig(path string, files []string, sets []string) (string, error) {
+	if IsJobSpec(path) {
+		spec, err := ReadJobSpec(path)
+		if err != nil {
+			return "", err
+		}
+		return GenerateJobXML(spec)
+	}
+	if !IsTemplate(path) && len(files) == 0 && len(sets) == 0 {
+		return getFileAsString(path)
+	}
+	values, err := LoadValues(files, sets)
+	if err != nil {
+		return "", err
+	}
+	return RenderTemplate(path, values)
+}
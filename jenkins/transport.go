@@ -0,0 +1,43 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+)
+
+// countingTransport is a http.RoundTripper that counts the requests sent
+// to the server and attaches the Jenkins.Context to them, gojenkins builds
+// the requests without it
+type countingTransport struct {
+	// requests is first to keep the 64-bit alignment of atomic operations
+	requests int64
+	base     http.RoundTripper
+	context  func() context.Context
+}
+
+// RoundTrip will count the request and send it with the base transport,
+// cancelling the context stops the request in progress
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt64(&t.requests, 1)
+	if t.context != nil {
+		if ctx := t.context(); ctx != nil {
+			req = req.WithContext(ctx)
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// RequestCount returns how many requests were sent to the server since
+// Init, including the requests of Init
+//
+// Args:
+//
+// Returns
+//	number of requests
+func (j *Jenkins) RequestCount() int64 {
+	if j.transport == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&j.transport.requests)
+}
@@ -0,0 +1,393 @@
+package jenkins
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/bndr/gojenkins"
+)
+
+// viewTreeDepth is how many levels of nested views are collected
+const viewTreeDepth = 5
+
+// ViewInfo is a view with its jobs and nested views
+type ViewInfo struct {
+	Class string `json:"_class"`
+	Name  string `json:"name"`
+	URL   string `json:"url"`
+	Jobs  []struct {
+		Name     string `json:"name"`
+		FullName string `json:"fullName"`
+	} `json:"jobs"`
+	Views []*ViewInfo `json:"views"`
+}
+
+// viewTree returns the tree= query for views with nested views up to depth
+func viewTree(depth int) string {
+	tree := "_class,name,url,jobs[name,fullName]"
+	if depth > 1 {
+		tree += ",views[" + viewTree(depth-1) + "]"
+	}
+	return tree
+}
+
+// viewBase returns the url path of a view
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	viewPath - view name, nested views are separated by /, i.e: parent/child
+//
+// Returns
+//	string, i.e: /job/folder/view/parent/view/child
+func viewBase(folder string, viewPath string) string {
+	base := jobBase(folder)
+	for _, name := range strings.Split(strings.Trim(viewPath, "/"), "/") {
+		if name != "" {
+			base += "/view/" + url.PathEscape(name)
+		}
+	}
+	return base
+}
+
+// getView will get a view from the root or from a folder
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	viewPath - view name, nested views are separated by /
+//
+// Returns
+//	view, nil or error
+func (j *Jenkins) getView(folder string, viewPath string) (*gojenkins.View, error) {
+	if strings.Trim(viewPath, "/") == "" {
+		return nil, errors.New("❌ missing view name")
+	}
+	view := &gojenkins.View{Jenkins: j.Instance, Raw: new(gojenkins.ViewResponse), Base: viewBase(folder, viewPath)}
+	resp, err := j.Instance.Requester.GetJSON(j.Context, view.Base, view.Raw, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkStatus(resp, view.Base); err != nil {
+		return nil, fmt.Errorf("unable to find the view %s: %s", viewPath, err)
+	}
+	return view, nil
+}
+
+// GetViews will collect all views, including nested views
+//
+// Args:
+//	folder - folder full name, empty for the root
+//
+// Returns
+//	list of views, nil or error
+func (j *Jenkins) GetViews(folder string) ([]*ViewInfo, error) {
+	endpoint := jobBase(folder)
+	if endpoint == "" {
+		endpoint = "/"
+	}
+	response := struct {
+		Views []*ViewInfo `json:"views"`
+	}{}
+	err := j.getJSON(endpoint, &response, map[string]string{"tree": "views[" + viewTree(viewTreeDepth) + "]"})
+	if err != nil {
+		return nil, err
+	}
+	return response.Views, nil
+}
+
+// ShowViews will show all views
+//
+// Args:
+//	folder - folder full name, empty for the root
+//
+// Returns:
+// 	error or nil
+func (j *Jenkins) ShowViews(folder string) error {
+	views, err := j.GetViews(folder)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "VIEW\tTYPE\tJOBS\tURL\n")
+	var show func(views []*ViewInfo, prefix string)
+	show = func(views []*ViewInfo, prefix string) {
+		for _, v := range views {
+			class := v.Class[strings.LastIndex(v.Class, ".")+1:]
+			fmt.Fprintf(w, "%s%s\t%s\t%d\t%s\n", prefix, v.Name, class, len(v.Jobs), v.URL)
+			show(v.Views, prefix+v.Name+"/")
+		}
+	}
+	show(views, "")
+	w.Flush()
+	return nil
+}
+
+// DeleteView will delete a view
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	viewPath - view name, nested views are separated by /
+//
+// Returns
+//	error or nil
+func (j *Jenkins) DeleteView(folder string, viewPath string) error {
+	view, err := j.getView(folder, viewPath)
+	if err != nil {
+		return err
+	}
+	resp, err := j.Instance.Requester.Post(j.Context, view.Base+"/doDelete", nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	return checkStatus(resp, view.Base+"/doDelete")
+}
+
+// AddJobToView will add a specific job to a view
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	viewPath - view name, nested views are separated by /
+//	jobName - job name, relative to the folder
+//
+// Returns
+//	error or nil
+func (j *Jenkins) AddJobToView(folder string, viewPath string, jobName string) error {
+	view, err := j.getView(folder, viewPath)
+	if err != nil {
+		return err
+	}
+	_, err = view.AddJob(j.Context, jobName)
+	return err
+}
+
+// RemoveJobFromView will remove a specific job from a view
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	viewPath - view name, nested views are separated by /
+//	jobName - job name, relative to the folder
+//
+// Returns
+//	error or nil
+func (j *Jenkins) RemoveJobFromView(folder string, viewPath string, jobName string) error {
+	view, err := j.getView(folder, viewPath)
+	if err != nil {
+		return err
+	}
+	_, err = view.DeleteJob(j.Context, jobName)
+	return err
+}
+
+// ViewGetConfig will show the config.xml of a view
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	viewPath - view name, nested views are separated by /
+//
+// Returns
+//	error or nil
+func (j *Jenkins) ViewGetConfig(folder string, viewPath string) error {
+	base := viewBase(folder, viewPath)
+	var config string
+	resp, err := j.Instance.Requester.GetXML(j.Context, base+"/config.xml", &config, nil)
+	if err != nil {
+		return err
+	}
+	if err = checkStatus(resp, base+"/config.xml"); err != nil {
+		return err
+	}
+	fmt.Println(config)
+	return nil
+}
+
+// ViewApplyConfig will update a view with the config.xml from a file
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	viewPath - view name, nested views are separated by /
+//	xmlFile - view described in XML format
+//
+// Returns
+//	error or nil
+func (j *Jenkins) ViewApplyConfig(folder string, viewPath string, xmlFile string) error {
+	config, err := ioutil.ReadFile(xmlFile)
+	if err != nil {
+		return err
+	}
+	view, err := j.getView(folder, viewPath)
+	if err != nil {
+		return err
+	}
+	resp, err := j.Instance.Requester.PostXML(j.Context, view.Base+"/config.xml", string(config), nil, nil)
+	if err != nil {
+		return err
+	}
+	return checkStatus(resp, view.Base+"/config.xml")
+}
+
+// SyncView will add and remove jobs from a view so the members are the
+// jobs whose full name matches include and does not match exclude
+//
+// Args:
+//	folder - folder full name, empty for the root
+//	viewPath - view name, nested views are separated by /
+//	include - regex of the job full names to include
+//	exclude - regex of the job full names to exclude, empty to exclude nothing
+//	dryRun - only show the changes
+//
+// Returns
+//	error or nil
+func (j *Jenkins) SyncView(folder string, viewPath string, include string, exclude string, dryRun bool) error {
+	includeRe, err := regexp.Compile(include)
+	if err != nil {
+		return fmt.Errorf("invalid include regex: %s", err)
+	}
+	var excludeRe *regexp.Regexp
+	if exclude != "" {
+		excludeRe, err = regexp.Compile(exclude)
+		if err != nil {
+			return fmt.Errorf("invalid exclude regex: %s", err)
+		}
+	}
+
+	view, err := j.getView(folder, viewPath)
+	if err != nil {
+		return err
+	}
+	members := struct {
+		Jobs []struct {
+			FullName string `json:"fullName"`
+		} `json:"jobs"`
+	}{}
+	err = j.getJSON(view.Base, &members, map[string]string{"tree": "jobs[fullName]"})
+	if err != nil {
+		return err
+	}
+	current := map[string]bool{}
+	for _, job := range members.Jobs {
+		current[job.FullName] = true
+	}
+
+	jobs, err := j.ListJobs(folder)
+	if err != nil {
+		return err
+	}
+	wanted := map[string]bool{}
+	var add []string
+	for _, job := range jobs {
+		if !includeRe.MatchString(job.FullName) {
+			continue
+		}
+		if excludeRe != nil && excludeRe.MatchString(job.FullName) {
+			continue
+		}
+		wanted[job.FullName] = true
+		if !current[job.FullName] {
+			add = append(add, job.FullName)
+		}
+	}
+	var remove []string
+	for name := range current {
+		if !wanted[name] {
+			remove = append(remove, name)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+
+	prefix := ""
+	if dryRun {
+		prefix = "(dry-run) "
+	}
+	for _, name := range add {
+		fmt.Printf("%s➕ %s\n", prefix, name)
+		if dryRun {
+			continue
+		}
+		if _, err = view.AddJob(j.Context, relativeName(folder, name)); err != nil {
+			return fmt.Errorf("unable to add job %s: %s", name, err)
+		}
+	}
+	for _, name := range remove {
+		fmt.Printf("%s➖ %s\n", prefix, name)
+		if dryRun {
+			continue
+		}
+		if _, err = view.DeleteJob(j.Context, relativeName(folder, name)); err != nil {
+			return fmt.Errorf("unable to remove job %s: %s", name, err)
+		}
+	}
+	fmt.Printf("%s✅ View %s: %d job(s), %d added, %d removed\n", prefix, viewPath, len(wanted), len(add), len(remove))
+	return nil
+}
+
+// viewRef is a view in the root or in a folder
+type viewRef struct {
+	folder string
+	path   string
+}
+
+// viewsContaining will find the views, in the root and in the job
+// folder, that list a job explicitly
+//
+// Args:
+//	fullName - job full name
+//
+// Returns
+//	list of views, nil or error
+func (j *Jenkins) viewsContaining(fullName string) ([]viewRef, error) {
+	owners := []string{""}
+	if parent := parentName(fullName); parent != "" {
+		owners = append(owners, parent)
+	}
+
+	var refs []viewRef
+	for _, owner := range owners {
+		views, err := j.GetViews(owner)
+		if err != nil {
+			return nil, err
+		}
+		var walk func(views []*ViewInfo, prefix string)
+		walk = func(views []*ViewInfo, prefix string) {
+			for _, v := range views {
+				walk(v.Views, prefix+v.Name+"/")
+				// AllView, MyView and NestedView do not have a job list
+				if strings.HasSuffix(v.Class, "AllView") || strings.HasSuffix(v.Class, "MyView") || strings.HasSuffix(v.Class, "NestedView") {
+					continue
+				}
+				for _, job := range v.Jobs {
+					if job.FullName == fullName {
+						refs = append(refs, viewRef{folder: owner, path: prefix + v.Name})
+						break
+					}
+				}
+			}
+		}
+		walk(views, "")
+	}
+	return refs, nil
+}
+
+// restoreViewMembership will add a job to views, failures are reported
+// but do not stop the other views
+//
+// Args:
+//	refs - views
+//	fullName - job full name
+func (j *Jenkins) restoreViewMembership(refs []viewRef, fullName string) {
+	for _, ref := range refs {
+		err := j.AddJobToView(ref.folder, ref.path, relativeName(ref.folder, fullName))
+		if err != nil {
+			fmt.Printf("⚠️  unable to add %s to view %s: %s\n", fullName, ref.path, err)
+			continue
+		}
+		fmt.Printf("✅ %s added to view %s\n", fullName, ref.path)
+	}
+}
@@ -0,0 +1,280 @@
+package jenkins
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ANSI sequences used to redraw the watch table
+const (
+	clearScreen = "\033[H\033[2J"
+	colorRed    = "\033[1;31m"
+	colorYellow = "\033[33m"
+	colorGreen  = "\033[32m"
+	colorReset  = "\033[0m"
+)
+
+// watchRow is a row of a watch table, state is compared between polls to
+// find what changed
+type watchRow struct {
+	key   string
+	cells []string
+	state string
+}
+
+// watchTable is a snapshot of the server shown by watch
+type watchTable struct {
+	header  []string
+	rows    []watchRow
+	summary string
+}
+
+// watchAlert returns true when a state change must be highlighted, i.e: a
+// job that started to fail
+type watchAlert func(previous string, current string) bool
+
+// watch will collect and redraw a table every interval until the context
+// is cancelled. Rows are marked with ! for alerts, ~ for other changes
+// and + for new rows since the last poll
+//
+// Args:
+//	out - where the table is drawn
+//	title - title of the table
+//	interval - time between polls
+//	collect - collects a snapshot from the server
+//	alert - which state changes are alerts
+//
+// Returns
+//	nil when cancelled or error
+func (j *Jenkins) watch(out io.Writer, title string, interval time.Duration, collect func() (*watchTable, error), alert watchAlert) error {
+	var previous map[string]string
+	for {
+		table, err := collect()
+		if err != nil {
+			if j.Context.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		current := map[string]string{}
+		marks := make([]string, len(table.rows))
+		alerts, changes, added := 0, 0, 0
+		for i, row := range table.rows {
+			current[row.key] = row.state
+			if previous == nil {
+				continue
+			}
+			prev, ok := previous[row.key]
+			switch {
+			case !ok:
+				marks[i] = "+"
+				added++
+			case prev != row.state && alert(prev, row.state):
+				marks[i] = "!"
+				alerts++
+			case prev != row.state:
+				marks[i] = "~"
+				changes++
+			}
+		}
+		removed := 0
+		for key := range previous {
+			if _, ok := current[key]; !ok {
+				removed++
+			}
+		}
+
+		// colors are added after tabwriter so they do not break the alignment
+		var buf bytes.Buffer
+		w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, " \t%s\n", strings.Join(table.header, "\t"))
+		for i, row := range table.rows {
+			mark := marks[i]
+			if mark == "" {
+				mark = " "
+			}
+			fmt.Fprintf(w, "%s\t%s\n", mark, strings.Join(row.cells, "\t"))
+		}
+		w.Flush()
+
+		fmt.Fprint(out, clearScreen)
+		fmt.Fprintf(out, "%s every %s, %s (Ctrl-C to stop)\n\n", title, interval, time.Now().Format("15:04:05"))
+		for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+			color := ""
+			if i > 0 {
+				switch marks[i-1] {
+				case "!":
+					color = colorRed
+				case "~":
+					color = colorYellow
+				case "+":
+					color = colorGreen
+				}
+			}
+			if color != "" {
+				line = color + line + colorReset
+			}
+			fmt.Fprintln(out, line)
+		}
+		fmt.Fprintf(out, "\n%s\n", table.summary)
+		if previous != nil {
+			fmt.Fprintf(out, "Since the last poll: %d alert(s), %d changed, %d new, %d removed\n", alerts, changes, added, removed)
+		}
+
+		previous = current
+		if err = j.sleep(interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// WatchJobs will redraw the status of all jobs every interval, jobs that
+// start to fail are highlighted
+//
+// Args:
+//	out - where the table is drawn
+//	interval - time between polls
+//
+// Returns
+//	nil when cancelled or error
+func (j *Jenkins) WatchJobs(out io.Writer, interval time.Duration) error {
+	collect := func() (*watchTable, error) {
+		jobs, err := j.ListJobs("", "lastBuild[number,result,timestamp]")
+		if err != nil {
+			return nil, err
+		}
+		table := &watchTable{header: []string{"JOB", "STATUS", "LAST BUILD", "STARTED"}}
+		failing := 0
+		for _, job := range jobs {
+			// running jobs keep the color of the previous build
+			color := strings.TrimSuffix(job.Color, "_anime")
+			if color == "red" {
+				failing++
+			}
+			status := colorStatus(job.Color)
+			if status == "" {
+				status = job.Color
+			}
+			number, started := "-", "-"
+			if job.LastBuild != nil {
+				number = fmt.Sprintf("#%d", job.LastBuild.Number)
+				started = job.LastBuildTime().Format("2006-01-02 15:04:05")
+			}
+			table.rows = append(table.rows, watchRow{
+				key:   job.FullName,
+				cells: []string{job.FullName, status, number, started},
+				state: color,
+			})
+		}
+		table.summary = fmt.Sprintf("Jobs: %d, failing: %d", len(jobs), failing)
+		return table, nil
+	}
+	alert := func(previous string, current string) bool {
+		return current == "red" && previous != "red"
+	}
+	return j.watch(out, "Jobs", interval, collect, alert)
+}
+
+// WatchQueue will redraw the build queue every interval, items that get
+// stuck are highlighted
+//
+// Args:
+//	out - where the table is drawn
+//	interval - time between polls
+//
+// Returns
+//	nil when cancelled or error
+func (j *Jenkins) WatchQueue(out io.Writer, interval time.Duration) error {
+	collect := func() (*watchTable, error) {
+		queue := struct {
+			Items []struct {
+				ID           int64  `json:"id"`
+				Blocked      bool   `json:"blocked"`
+				Stuck        bool   `json:"stuck"`
+				InQueueSince int64  `json:"inQueueSince"`
+				Why          string `json:"why"`
+				Task         struct {
+					Name string `json:"name"`
+				} `json:"task"`
+			} `json:"items"`
+		}{}
+		err := j.getJSON("/queue", &queue, map[string]string{"tree": "items[id,blocked,stuck,inQueueSince,why,task[name]]"})
+		if err != nil {
+			return nil, err
+		}
+		table := &watchTable{header: []string{"ID", "JOB", "STATE", "WAITING", "WHY"}}
+		stuck := 0
+		for _, item := range queue.Items {
+			state := "waiting"
+			switch {
+			case item.Stuck:
+				state = "stuck"
+				stuck++
+			case item.Blocked:
+				state = "blocked"
+			}
+			why := strings.Replace(item.Why, "\n", " ", -1)
+			if len(why) > 60 {
+				why = why[:57] + "..."
+			}
+			since := time.Unix(0, item.InQueueSince*int64(time.Millisecond))
+			table.rows = append(table.rows, watchRow{
+				key:   fmt.Sprintf("%d", item.ID),
+				cells: []string{fmt.Sprintf("%d", item.ID), item.Task.Name, state, time.Since(since).Round(time.Second).String(), why},
+				state: state,
+			})
+		}
+		table.summary = fmt.Sprintf("Items in the build queue: %d, stuck: %d", len(queue.Items), stuck)
+		return table, nil
+	}
+	alert := func(previous string, current string) bool {
+		return current == "stuck"
+	}
+	return j.watch(out, "Build queue", interval, collect, alert)
+}
+
+// WatchNodes will redraw the status and executor usage of all nodes every
+// interval, nodes going offline are highlighted
+//
+// Args:
+//	out - where the table is drawn
+//	interval - time between polls
+//
+// Returns
+//	nil when cancelled or error
+func (j *Jenkins) WatchNodes(out io.Writer, interval time.Duration) error {
+	collect := func() (*watchTable, error) {
+		nodes, err := j.GetNodeExecutors()
+		if err != nil {
+			return nil, err
+		}
+		table := &watchTable{header: []string{"NODE", "STATUS", "BUSY/TOTAL", "REASON"}}
+		offline, busyTotal, executorsTotal := 0, 0, 0
+		for _, node := range nodes {
+			state := "online"
+			if node.Offline || node.TemporarilyOffline {
+				state = "offline"
+				offline++
+			}
+			busy := node.Busy()
+			busyTotal += busy
+			executorsTotal += node.NumExecutors
+			table.rows = append(table.rows, watchRow{
+				key:   node.DisplayName,
+				cells: []string{node.DisplayName, state, fmt.Sprintf("%d/%d", busy, node.NumExecutors), node.OfflineCauseReason},
+				state: state,
+			})
+		}
+		table.summary = fmt.Sprintf("Nodes: %d, offline: %d, executors busy: %d/%d", len(nodes), offline, busyTotal, executorsTotal)
+		return table, nil
+	}
+	alert := func(previous string, current string) bool {
+		return current == "offline"
+	}
+	return j.watch(out, "Nodes", interval, collect, alert)
+}